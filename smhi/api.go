@@ -0,0 +1,172 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// apiVersion holds the version of an API used for requests, safe for concurrent use
+type apiVersion struct {
+	mu  sync.RWMutex
+	key string
+}
+
+// get returns the version, "latest" if unset
+func (v *apiVersion) get() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if v.key == "" {
+		return defaultAPIVersion
+	}
+	return v.key
+}
+
+// set sets the version
+func (v *apiVersion) set(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.key = key
+}
+
+// APIService is a service for discovering the versions of the API
+type APIService service
+
+// API is the root of the API listing the available versions
+type API struct {
+	Key     string    `json:"key,omitempty"`
+	Updated Timestamp `json:"updated,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	Summary string    `json:"summary,omitempty"`
+	Link    []Link    `json:"link,omitempty"`
+	Version []Version `json:"version,omitempty"`
+}
+
+// Version is a version of the API
+// Resource is only set on versions retrieved with GetVersion
+type Version struct {
+	Key      string     `json:"key,omitempty"`
+	Updated  Timestamp  `json:"updated,omitempty"`
+	Title    string     `json:"title,omitempty"`
+	Summary  string     `json:"summary,omitempty"`
+	Link     []Link     `json:"link,omitempty"`
+	Resource []Resource `json:"resource,omitempty"`
+}
+
+// Resource is a parameter available in a version of the API
+type Resource struct {
+	Key     string `json:"key,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Summary string `json:"summary,omitempty"`
+	Unit    string `json:"unit,omitempty"`
+	Link    []Link `json:"link,omitempty"`
+	GeoBox  GeoBox `json:"geoBox,omitempty"`
+}
+
+// GeoBox is the area covered by the stations of a resource
+type GeoBox struct {
+	MinLatitude  float64 `json:"minLatitude,omitempty"`
+	MinLongitude float64 `json:"minLongitude,omitempty"`
+	MaxLatitude  float64 `json:"maxLatitude,omitempty"`
+	MaxLongitude float64 `json:"maxLongitude,omitempty"`
+}
+
+// GetAPI retrieves the root of the API
+func (s *APIService) GetAPI(ctx context.Context) (*API, *http.Response, error) {
+	return (*service)(s).getAPI(ctx)
+}
+
+// getAPI retrieves the root of the API of the service
+func (s *service) getAPI(ctx context.Context) (*API, *http.Response, error) {
+	req, err := s.newRequest("GET", "api.json")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	api := &API{}
+	resp, err := s.client.Do(ctx, req, api)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return api, resp, nil
+}
+
+// GetVersions retrieves the available versions of the API
+func (s *APIService) GetVersions(ctx context.Context) ([]Version, *http.Response, error) {
+	api, resp, err := s.GetAPI(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return api.Version, resp, nil
+}
+
+// GetVersion retrieves a version of the API with its resources by following
+// the version link of the API root
+// The key "latest" matches the most recently updated version
+func (s *APIService) GetVersion(ctx context.Context, key string) (*Version, *http.Response, error) {
+	versions, resp, err := s.GetVersions(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	v, ok := findVersion(versions, key)
+	if !ok {
+		return nil, resp, fmt.Errorf("smhi: API version %q: %w", key, ErrNotFound)
+	}
+	link, ok := findLink(v.Link, "version", FormatJSON)
+	if !ok {
+		return nil, resp, fmt.Errorf("smhi: API version %q has no version link", v.Key)
+	}
+
+	version := &Version{}
+	resp, err = (*service)(s).follow(ctx, link, version)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return version, resp, nil
+}
+
+// PinVersion resolves the meteorological observation API version of the client
+// to the concrete version it currently refers to, so that later requests keep
+// using it once a newer version is released
+// The ocean and hydrological services keep their own versions
+// It is safe to call while the client is in use, requests already started keep
+// the version they were made with
+func (s *APIService) PinVersion(ctx context.Context) (string, *http.Response, error) {
+	versions, resp, err := s.GetVersions(ctx)
+	if err != nil {
+		return "", resp, err
+	}
+
+	v, ok := findVersion(versions, s.client.version())
+	if !ok {
+		return "", resp, fmt.Errorf("smhi: API version %q: %w", s.client.version(), ErrNotFound)
+	}
+	s.client.apiVersion.set(v.Key)
+
+	return v.Key, resp, nil
+}
+
+// findVersion returns the version with the key, or the most recently updated
+// version for the key "latest"
+func findVersion(versions []Version, key string) (Version, bool) {
+	var found Version
+	ok := false
+	for _, v := range versions {
+		if key == defaultAPIVersion {
+			if !ok || v.Updated > found.Updated {
+				found, ok = v, true
+			}
+		} else if v.Key == key {
+			return v, true
+		}
+	}
+
+	return found, ok
+}
@@ -0,0 +1,131 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+const apiJSON = `{
+	"key": "metobs",
+	"updated": 1590000000000,
+	"title": "Meteorologiska observationer",
+	"summary": "Meteorologiska observationer",
+	"link": [{"rel": "api", "type": "application/json", "href": "%[1]s/api.json"}],
+	"version": [
+		{"key": "1.0", "updated": 1590000000000, "title": "Version 1.0", "summary": "Version 1.0",
+			"link": [{"rel": "version", "type": "application/json", "href": "%[1]s/api/version/1.0.json"}]},
+		{"key": "0.9", "updated": 1490000000000, "title": "Version 0.9", "summary": "Version 0.9",
+			"link": [{"rel": "version", "type": "application/json", "href": "%[1]s/api/version/0.9.json"}]}
+	]
+}`
+
+func TestAPIService_GetVersion(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, apiJSON, serverURL+baseURLPath)
+	})
+	mux.HandleFunc("/api/version/1.0.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"key": "1.0",
+			"updated": 1590000000000,
+			"title": "Version 1.0",
+			"resource": [{
+				"key": "1",
+				"title": "Lufttemperatur",
+				"summary": "momentanvärde, 1 gång/tim",
+				"unit": "degree celsius",
+				"link": [{"rel": "parameter", "type": "application/json", "href": "https://example.com/api/version/1.0/parameter/1.json"}],
+				"geoBox": {"minLatitude": 55.3, "minLongitude": 11.1, "maxLatitude": 69.1, "maxLongitude": 24.2}
+			}]
+		}`)
+	})
+
+	version, _, err := client.API.GetVersion(context.Background(), "latest")
+	if err != nil {
+		t.Fatalf("GetVersion returned error: %v", err)
+	}
+
+	want := &Version{
+		Key:     "1.0",
+		Updated: 1590000000000,
+		Title:   "Version 1.0",
+		Resource: []Resource{{
+			Key:     "1",
+			Title:   "Lufttemperatur",
+			Summary: "momentanvärde, 1 gång/tim",
+			Unit:    "degree celsius",
+			Link:    []Link{{Rel: "parameter", Type: "application/json", Href: "https://example.com/api/version/1.0/parameter/1.json"}},
+			GeoBox:  GeoBox{MinLatitude: 55.3, MinLongitude: 11.1, MaxLatitude: 69.1, MaxLongitude: 24.2},
+		}},
+	}
+	if !reflect.DeepEqual(version, want) {
+		t.Errorf("GetVersion returned %+v, want %+v", version, want)
+	}
+
+	if _, _, err := client.API.GetVersion(context.Background(), "2.0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetVersion returned error %v, want ErrNotFound", err)
+	}
+}
+
+func TestAPIService_PinVersion(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, apiJSON, serverURL+baseURLPath)
+	})
+	mux.HandleFunc("/api/version/1.0/parameter/4/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"updated": 1590000000000}`)
+	})
+
+	version, _, err := client.API.PinVersion(context.Background())
+	if err != nil {
+		t.Fatalf("PinVersion returned error: %v", err)
+	}
+	if version != "1.0" || client.APIVersion() != "1.0" {
+		t.Errorf("PinVersion returned %q and set %q, want %q", version, client.APIVersion(), "1.0")
+	}
+
+	if _, _, err := client.Observations.GetData(context.Background(), 4, 97100, "latest-hour"); err != nil {
+		t.Errorf("GetData with pinned version returned error: %v", err)
+	}
+}
+
+func TestAPIService_PinVersion_concurrent(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, apiJSON, serverURL+baseURLPath)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.API.PinVersion(context.Background())
+		}()
+		go func() {
+			defer wg.Done()
+			client.Observations.GetData(context.Background(), 4, 97100, PeriodLatestHour)
+		}()
+	}
+	wg.Wait()
+
+	if got, want := client.APIVersion(), "1.0"; got != want {
+		t.Errorf("APIVersion is %q, want %q", got, want)
+	}
+}
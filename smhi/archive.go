@@ -0,0 +1,299 @@
+package smhi
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header cells introducing the blocks of a corrected archive
+const (
+	archiveStationHeader   = "Stationsnamn"
+	archiveParameterHeader = "Parameternamn"
+	archivePositionHeader  = "Tidsperiod (fr.o.m)"
+	archiveDateHeader      = "Datum"
+	archiveTimeHeader      = "Tid (UTC)"
+	archiveFromHeader      = "Från Datum Tid (UTC)"
+	archiveToHeader        = "Till Datum Tid (UTC)"
+	archiveRefHeader       = "Representativ"
+	archiveQualityHeader   = "Kvalitet"
+)
+
+const archiveTimeLayout = "2006-01-02 15:04:05"
+
+// ArchiveReader streams the values of a corrected archive
+// The station, parameter and position information is parsed from the header
+// of the archive before the first value is read
+type ArchiveReader struct {
+	Parameter ParameterData
+	Station   StationData
+	Position  []PositionData
+
+	body    io.Closer
+	r       *csv.Reader
+	columns archiveColumns
+	opts    *dataOptions
+}
+
+// archiveColumns holds the column indexes of the value table, -1 when missing
+type archiveColumns struct {
+	date    int
+	time    int
+	from    int
+	to      int
+	ref     int
+	value   int
+	quality int
+}
+
+// NewArchiveReader parses the header of a corrected archive in CSV format
+func NewArchiveReader(r io.Reader, opts ...DataOption) (*ArchiveReader, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = ';'
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	ar := &ArchiveReader{r: cr, opts: newDataOptions(opts)}
+	if err := ar.readHeader(); err != nil {
+		return nil, err
+	}
+
+	return ar, nil
+}
+
+func (ar *ArchiveReader) readHeader() error {
+	for {
+		rec, err := ar.r.Read()
+		if err == io.EOF {
+			return fmt.Errorf("corrected archive has no value table")
+		}
+		if err != nil {
+			return err
+		}
+
+		switch strings.TrimPrefix(rec[0], "\ufeff") {
+		case archiveStationHeader:
+			rec, err = ar.r.Read()
+			if err != nil {
+				return fmt.Errorf("corrected archive station: %v", err)
+			}
+			ar.Station = StationData{
+				Name:  field(rec, 0),
+				Key:   field(rec, 1),
+				Owner: field(rec, 2),
+			}
+			if h := field(rec, 3); h != "" {
+				height, err := strconv.ParseFloat(h, 32)
+				if err != nil {
+					return fmt.Errorf("corrected archive station height %q: %v", h, err)
+				}
+				ar.Station.Height = float32(height)
+			}
+		case archiveParameterHeader:
+			rec, err = ar.r.Read()
+			if err != nil {
+				return fmt.Errorf("corrected archive parameter: %v", err)
+			}
+			ar.Parameter = ParameterData{
+				Name:    field(rec, 0),
+				Summary: field(rec, 1),
+				Unit:    field(rec, 2),
+			}
+		case archivePositionHeader:
+			return ar.readPositions()
+		case archiveDateHeader, archiveFromHeader:
+			ar.columns = newArchiveColumns(rec)
+			return nil
+		}
+	}
+}
+
+// readPositions reads position rows until the value table header
+func (ar *ArchiveReader) readPositions() error {
+	for {
+		rec, err := ar.r.Read()
+		if err == io.EOF {
+			return fmt.Errorf("corrected archive has no value table")
+		}
+		if err != nil {
+			return err
+		}
+
+		if rec[0] == archiveDateHeader || rec[0] == archiveFromHeader {
+			ar.columns = newArchiveColumns(rec)
+			return nil
+		}
+
+		p, err := parseArchivePosition(rec)
+		if err != nil {
+			return err
+		}
+		ar.Position = append(ar.Position, p)
+	}
+}
+
+func parseArchivePosition(rec []string) (PositionData, error) {
+	from, err := parseArchiveTime(field(rec, 0))
+	if err != nil {
+		return PositionData{}, err
+	}
+	to, err := parseArchiveTime(field(rec, 1))
+	if err != nil {
+		return PositionData{}, err
+	}
+
+	var coords [3]float64
+	for i := range coords {
+		s := field(rec, i+2)
+		coords[i], err = strconv.ParseFloat(s, 32)
+		if err != nil {
+			return PositionData{}, fmt.Errorf("corrected archive position %q: %v", s, err)
+		}
+	}
+
+	return PositionData{
+		From:      from,
+		To:        to,
+		Height:    float32(coords[0]),
+		Latitude:  float32(coords[1]),
+		Longitude: float32(coords[2]),
+	}, nil
+}
+
+func newArchiveColumns(header []string) archiveColumns {
+	c := archiveColumns{date: -1, time: -1, from: -1, to: -1, ref: -1, value: -1, quality: -1}
+	for i, h := range header {
+		switch {
+		case h == archiveDateHeader:
+			c.date = i
+		case h == archiveTimeHeader:
+			c.time = i
+		case h == archiveFromHeader:
+			c.from = i
+		case h == archiveToHeader:
+			c.to = i
+		case strings.HasPrefix(h, archiveRefHeader):
+			c.ref = i
+		case h == archiveQualityHeader && c.quality == -1:
+			c.quality = i
+			c.value = i - 1
+		}
+	}
+
+	return c
+}
+
+// Next returns the next value of the archive, or io.EOF when all values are read
+func (ar *ArchiveReader) Next() (ObservationValue, error) {
+	for {
+		v, err := ar.next()
+		if err != nil || ar.opts.include(v) {
+			return v, err
+		}
+	}
+}
+
+func (ar *ArchiveReader) next() (ObservationValue, error) {
+	rec, err := ar.r.Read()
+	if err != nil {
+		return ObservationValue{}, err
+	}
+
+	c := ar.columns
+	v := ObservationValue{
+		Value:   field(rec, c.value),
+		Quality: Quality(field(rec, c.quality)),
+		Ref:     field(rec, c.ref),
+	}
+
+	if c.date >= 0 {
+		ts := field(rec, c.date)
+		if c.time >= 0 {
+			ts += " " + field(rec, c.time)
+		} else {
+			ts += " 00:00:00"
+		}
+		if v.Date, err = parseArchiveTime(ts); err != nil {
+			return ObservationValue{}, err
+		}
+	}
+	if c.from >= 0 {
+		if v.From, err = parseArchiveTime(field(rec, c.from)); err != nil {
+			return ObservationValue{}, err
+		}
+	}
+	if c.to >= 0 {
+		if v.To, err = parseArchiveTime(field(rec, c.to)); err != nil {
+			return ObservationValue{}, err
+		}
+	}
+
+	return v, nil
+}
+
+// Close closes the underlying response body
+func (ar *ArchiveReader) Close() error {
+	if ar.body == nil {
+		return nil
+	}
+	return ar.body.Close()
+}
+
+func field(rec []string, i int) string {
+	if i < 0 || i >= len(rec) {
+		return ""
+	}
+	return strings.TrimSpace(rec[i])
+}
+
+// parseArchiveTime parses a UTC time from the archive
+func parseArchiveTime(s string) (Timestamp, error) {
+	t, err := time.Parse(archiveTimeLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("corrected archive time %q: %v", s, err)
+	}
+	return NewTimestamp(t), nil
+}
+
+func getArchive(ctx context.Context, s *service, parameter int, station uint32, opts ...DataOption) (*ArchiveReader, *http.Response, error) {
+	link, resp, err := s.resolve(ctx, stationPath(parameter, station, "period", PeriodCorrectedArchive, "data", FormatCSV)...)
+	if err != nil {
+		return nil, resp, err
+	}
+	req, err := s.newRequest("GET", link.Href)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The archive is streamed, which the cache would defeat by buffering it
+	resp, err = s.client.send(withoutCache(ctx), req)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, resp, err
+	}
+
+	ar, err := NewArchiveReader(resp.Body, opts...)
+	if err != nil {
+		resp.Body.Close()
+		return nil, resp, err
+	}
+	ar.body = resp.Body
+	ar.Parameter.Key = strconv.Itoa(parameter)
+
+	return ar, resp, nil
+}
+
+// GetCorrectedArchive retrieves the corrected archive for a parameter from a station
+// The values are streamed from the response, and the reader must be closed when done
+func (s *ObservationService) GetCorrectedArchive(ctx context.Context, parameter int, station uint32, opts ...DataOption) (*ArchiveReader, *http.Response, error) {
+	return getArchive(ctx, (*service)(s), parameter, station, opts...)
+}
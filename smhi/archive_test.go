@@ -0,0 +1,189 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const hourlyArchive = `Stationsnamn;Stationsnummer;Stationsnät;Mäthöjd (meter över marken)
+Tullinge A;97100;SMHIs stationsnät;2.0
+
+Parameternamn;Beskrivning;Enhet
+Lufttemperatur;momentanvärde, 1 gång/tim;degree celsius
+
+Tidsperiod (fr.o.m);Tidsperiod (t.o.m);Höjd (meter över havet);Latitud (decimalgrader);Longitud (decimalgrader)
+1995-12-15 00:00:00;2018-08-05 12:00:00;45.0;59.1789;17.9125
+
+Datum;Tid (UTC);Lufttemperatur;Kvalitet;;Tidsutsnitt:
+1995-12-15;06:00:00;-3.2;G;;Kvalitetskontrollerade historiska data (utom de senaste 3 mån)
+1995-12-15;07:00:00;-3.4;Y;;Tidsperiod (fr.o.m.) = 1995-12-15 06:00:00 (UTC)
+`
+
+const dailyArchive = `Stationsnamn;Stationsnummer;Stationsnät;Mäthöjd (meter över marken)
+Tullinge A;97100;SMHIs stationsnät;2.0
+
+Parameternamn;Beskrivning;Enhet
+Lufttemperatur;medelvärde 1 dygn, 1 gång/dygn, kl 00;degree celsius
+
+Tidsperiod (fr.o.m);Tidsperiod (t.o.m);Höjd (meter över havet);Latitud (decimalgrader);Longitud (decimalgrader)
+1990-01-01 00:00:00;1995-12-14 23:59:59;44.0;59.1800;17.9100
+1995-12-15 00:00:00;2018-08-05 12:00:00;45.0;59.1789;17.9125
+
+Från Datum Tid (UTC);Till Datum Tid (UTC);Representativt dygn;Lufttemperatur;Kvalitet;;Tidsutsnitt:
+1996-01-01 00:00:01;1996-01-02 00:00:00;1996-01-01;-4.5;G;;Kvalitetskontrollerade historiska data (utom de senaste 3 mån)
+`
+
+func readArchive(t *testing.T, ar *ArchiveReader) []ObservationValue {
+	var values []ObservationValue
+	for {
+		v, err := ar.Next()
+		if err == io.EOF {
+			return values
+		}
+		if err != nil {
+			t.Fatalf("ArchiveReader.Next returned error: %v", err)
+		}
+		values = append(values, v)
+	}
+}
+
+func TestObservationService_GetCorrectedArchive_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/corrected-archive/data.csv", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, hourlyArchive)
+	})
+
+	ar, _, err := client.Observations.GetCorrectedArchive(context.Background(), 1, 97100)
+	if err != nil {
+		t.Fatalf("Observations.GetCorrectedArchive returned error: %v", err)
+	}
+	defer ar.Close()
+
+	wantStation := StationData{Key: "97100", Name: "Tullinge A", Owner: "SMHIs stationsnät", Height: 2.0}
+	if !reflect.DeepEqual(ar.Station, wantStation) {
+		t.Errorf("ArchiveReader.Station is %+v, want %+v", ar.Station, wantStation)
+	}
+
+	wantParameter := ParameterData{Key: "1", Name: "Lufttemperatur", Summary: "momentanvärde, 1 gång/tim", Unit: "degree celsius"}
+	if !reflect.DeepEqual(ar.Parameter, wantParameter) {
+		t.Errorf("ArchiveReader.Parameter is %+v, want %+v", ar.Parameter, wantParameter)
+	}
+
+	wantPosition := []PositionData{
+		{From: 818985600000, To: 1533470400000, Height: 45.0, Latitude: 59.1789, Longitude: 17.9125},
+	}
+	if !reflect.DeepEqual(ar.Position, wantPosition) {
+		t.Errorf("ArchiveReader.Position is %+v, want %+v", ar.Position, wantPosition)
+	}
+
+	want := []ObservationValue{
+		{Date: 819007200000, Value: "-3.2", Quality: "G"},
+		{Date: 819010800000, Value: "-3.4", Quality: "Y"},
+	}
+	if values := readArchive(t, ar); !reflect.DeepEqual(values, want) {
+		t.Errorf("ArchiveReader values are %+v, want %+v", values, want)
+	}
+}
+
+func TestObservationService_GetCorrectedArchive_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/1/station/12345/period/corrected-archive/data.csv", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Observations.GetCorrectedArchive(context.Background(), 1, 12346)
+	if err == nil {
+		t.Fatalf("Observations.GetCorrectedArchive expected error")
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Observations.GetCorrectedArchive returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestNewArchiveReader_intervals(t *testing.T) {
+	ar, err := NewArchiveReader(strings.NewReader(dailyArchive))
+	if err != nil {
+		t.Fatalf("NewArchiveReader returned error: %v", err)
+	}
+
+	if got := len(ar.Position); got != 2 {
+		t.Errorf("ArchiveReader has %d positions, want 2", got)
+	}
+
+	want := []ObservationValue{
+		{From: 820454401000, To: 820540800000, Ref: "1996-01-01", Value: "-4.5", Quality: "G"},
+	}
+	if values := readArchive(t, ar); !reflect.DeepEqual(values, want) {
+		t.Errorf("ArchiveReader values are %+v, want %+v", values, want)
+	}
+}
+
+func TestNewArchiveReader_noTable(t *testing.T) {
+	if _, err := NewArchiveReader(strings.NewReader("Stationsnamn;Stationsnummer\nTullinge A;97100\n")); err == nil {
+		t.Errorf("NewArchiveReader expected error for an archive without values")
+	}
+}
+
+func TestObservationService_GetCorrectedArchive_streamed(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	header, rows := hourlyArchive[:strings.Index(hourlyArchive, "1995-12-15;07")], hourlyArchive[strings.Index(hourlyArchive, "1995-12-15;07"):]
+	release := make(chan struct{})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/corrected-archive/data.csv", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=3600")
+		fmt.Fprint(w, header)
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-time.After(5 * time.Second):
+		}
+		fmt.Fprint(w, rows)
+	})
+
+	// The first value must be readable before the rest of the archive is sent
+	done := make(chan []ObservationValue)
+	go func() {
+		ar, _, err := client.Observations.GetCorrectedArchive(context.Background(), 1, 97100)
+		if err != nil {
+			t.Errorf("Observations.GetCorrectedArchive returned error: %v", err)
+			close(done)
+			return
+		}
+		defer ar.Close()
+
+		v, err := ar.Next()
+		if err != nil {
+			t.Errorf("ArchiveReader.Next returned error: %v", err)
+		}
+		done <- []ObservationValue{v}
+		done <- readArchive(t, ar)
+	}()
+
+	select {
+	case first := <-done:
+		if len(first) != 1 || first[0].Value != "-3.2" {
+			t.Errorf("First archive value is %+v", first)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Archive was not read incrementally")
+	}
+	close(release)
+
+	if rest := <-done; len(rest) != 1 || rest[0].Value != "-3.4" {
+		t.Errorf("Remaining archive values are %+v", rest)
+	}
+	archiveURL := serverURL + baseURLPath + "/api/version/latest/parameter/1/station/97100/period/corrected-archive/data.csv"
+	if _, ok := client.Cache.Get(archiveURL); ok {
+		t.Errorf("Archive was cached")
+	}
+}
@@ -0,0 +1,312 @@
+package smhi
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheHeader is set on responses served from the cache
+const CacheHeader = "X-From-Cache"
+
+// maxCacheBodySize limits the size of the responses stored in the cache, larger
+// responses are streamed to the caller
+const maxCacheBodySize = 1 << 20
+
+// skipCacheKey marks contexts of requests that bypass the cache
+type skipCacheKey struct{}
+
+// withoutCache returns a context for requests that bypass the cache, such as
+// requests for responses that are streamed
+func withoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+// skipCache reports whether requests with the context bypass the cache
+func skipCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	return skip
+}
+
+// ErrCacheMiss is returned in offline mode for requests not in the cache
+var ErrCacheMiss = errors.New("smhi: response not in cache")
+
+// Cache stores responses keyed by URL
+type Cache interface {
+	Get(key string) (*CacheEntry, bool)
+	Set(key string, entry *CacheEntry)
+	Delete(key string)
+}
+
+// CacheEntry is a cached response with its validators
+type CacheEntry struct {
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+	ETag         string
+	LastModified string
+	Expires      time.Time // The entry is fresh until Expires and revalidated after
+}
+
+// fresh reports whether the entry may be served without revalidation
+func (e *CacheEntry) fresh(now time.Time) bool {
+	return now.Before(e.Expires)
+}
+
+// response creates a response for the request from the entry
+func (e *CacheEntry) response(req *http.Request) *http.Response {
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(CacheHeader, "1")
+
+	return &http.Response{
+		Status:        strconv.Itoa(e.StatusCode) + " " + http.StatusText(e.StatusCode),
+		StatusCode:    e.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
+
+// cacheDirectives parses the Cache-Control header
+func cacheDirectives(h http.Header) map[string]string {
+	directives := map[string]string{}
+	for _, part := range strings.Split(h.Get("Cache-Control"), ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		if len(kv) == 2 {
+			directives[key] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		} else {
+			directives[key] = ""
+		}
+	}
+	return directives
+}
+
+// cacheable reports whether the response may be stored
+func cacheable(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	_, noStore := cacheDirectives(resp.Header)["no-store"]
+	return !noStore
+}
+
+// expires returns the time until which a response is fresh
+func expires(h http.Header, now time.Time) time.Time {
+	directives := cacheDirectives(h)
+	if _, ok := directives["no-cache"]; ok {
+		return now
+	}
+	if maxAge, ok := directives["max-age"]; ok {
+		if seconds, err := strconv.Atoi(maxAge); err == nil {
+			return now.Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	if t, err := http.ParseTime(h.Get("Expires")); err == nil {
+		return t
+	}
+	return now
+}
+
+// newCacheEntry creates an entry from a response and its body
+func newCacheEntry(resp *http.Response, body []byte, now time.Time) *CacheEntry {
+	return &CacheEntry{
+		StatusCode:   resp.StatusCode,
+		Header:       resp.Header.Clone(),
+		Body:         body,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Expires:      expires(resp.Header, now),
+	}
+}
+
+// sendCached serves GET requests from the cache of the client, revalidating
+// stale entries with conditional requests
+// In offline mode requests are only served from the cache, stale or not
+func (c *Client) sendCached(ctx context.Context, req *http.Request) (*http.Response, error) {
+	key := req.URL.String()
+	if c.Offline {
+		if c.Cache != nil && req.Method == http.MethodGet {
+			if entry, ok := c.Cache.Get(key); ok {
+				return entry.response(req), nil
+			}
+		}
+		return nil, fmt.Errorf("%w: %s %s", ErrCacheMiss, req.Method, key)
+	}
+
+	if c.Cache == nil || req.Method != http.MethodGet || skipCache(ctx) {
+		return c.retry(ctx, req)
+	}
+
+	entry, ok := c.Cache.Get(key)
+	if ok && entry.fresh(time.Now()) {
+		return entry.response(req), nil
+	}
+
+	if ok {
+		req = req.Clone(ctx)
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	resp, err := c.retry(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		drain(resp)
+		entry.Expires = expires(resp.Header, time.Now())
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			entry.ETag = etag
+		}
+		c.Cache.Set(key, entry)
+		return entry.response(req), nil
+	}
+
+	if !cacheable(resp) || resp.ContentLength > maxCacheBodySize {
+		return resp, nil
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxCacheBodySize+1))
+	if err != nil {
+		resp.Body.Close()
+		return resp, err
+	}
+	if len(body) > maxCacheBodySize {
+		// Too large to cache, stream the rest of the body
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	c.Cache.Set(key, newCacheEntry(resp, body, time.Now()))
+
+	return resp, nil
+}
+
+// LRUCache is an in-memory cache evicting the least recently used entries
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int64
+	size       int64
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type lruItem struct {
+	key   string
+	entry *CacheEntry
+}
+
+// NewLRUCache creates a cache holding up to maxEntries responses with bodies
+// of up to maxBytes in total
+// A maxBytes of zero or less disables the size limit
+func NewLRUCache(maxEntries int, maxBytes int64) *LRUCache {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &LRUCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+	}
+}
+
+// Get returns the entry for the key
+func (c *LRUCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+
+	entry := *el.Value.(*lruItem).entry
+	return &entry, true
+}
+
+// Set stores the entry for the key, evicting the least recently used entries when full
+func (c *LRUCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		item := el.Value.(*lruItem)
+		c.size += int64(len(entry.Body)) - int64(len(item.entry.Body))
+		item.entry = entry
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[key] = c.order.PushFront(&lruItem{key: key, entry: entry})
+		c.size += int64(len(entry.Body))
+	}
+
+	for c.order.Len() > c.maxEntries || c.maxBytes > 0 && c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes the entry for the key
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+}
+
+// remove removes an element of the cache
+func (c *LRUCache) remove(el *list.Element) {
+	item := el.Value.(*lruItem)
+	c.order.Remove(el)
+	delete(c.entries, item.key)
+	c.size -= int64(len(item.entry.Body))
+}
+
+// Len returns the number of entries in the cache
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Size returns the total size in bytes of the bodies in the cache
+func (c *LRUCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.size
+}
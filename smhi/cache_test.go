@@ -0,0 +1,159 @@
+package smhi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDo_cacheFresh(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Cache-Control", "max-age=3600")
+		fmt.Fprint(w, `{"Name":"test"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		req, _ := client.NewRequest("GET", ".")
+		body := new(struct{ Name string })
+		resp, err := client.Do(context.Background(), req, body)
+		if err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		if body.Name != "test" {
+			t.Errorf("Response body name is %q, want %q", body.Name, "test")
+		}
+		if got, want := resp.Header.Get(CacheHeader) != "", i > 0; got != want {
+			t.Errorf("Response %d from cache is %v, want %v", i, got, want)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Server was called %d times, want 1", got)
+	}
+}
+
+func TestDo_cacheRevalidate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, `{"Name":"test"}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		req, _ := client.NewRequest("GET", ".")
+		body := new(struct{ Name string })
+		resp, err := client.Do(context.Background(), req, body)
+		if err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+		if body.Name != "test" {
+			t.Errorf("Response body name is %q, want %q", body.Name, "test")
+		}
+		if got, want := resp.StatusCode, http.StatusOK; got != want {
+			t.Errorf("Response status is %d, want %d", got, want)
+		}
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("Server was called %d times, want 2", got)
+	}
+}
+
+func TestDo_cacheNoStore(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store, max-age=3600")
+		fmt.Fprint(w, `{"Name":"test"}`)
+	})
+
+	req, _ := client.NewRequest("GET", ".")
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if _, ok := client.Cache.Get(req.URL.String()); ok {
+		t.Errorf("Response with no-store was cached")
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2, 0)
+	c.Set("a", &CacheEntry{Body: []byte("a")})
+	c.Set("b", &CacheEntry{Body: []byte("b")})
+	c.Get("a")
+	c.Set("c", &CacheEntry{Body: []byte("c")})
+
+	if got, want := c.Len(), 2; got != want {
+		t.Errorf("Len is %d, want %d", got, want)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Least recently used entry was not evicted")
+	}
+	entry, ok := c.Get("a")
+	if !ok || !reflect.DeepEqual(entry.Body, []byte("a")) {
+		t.Errorf("Get(a) returned %+v, %v", entry, ok)
+	}
+
+	c.Delete("a")
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Deleted entry was returned")
+	}
+}
+
+func TestLRUCache_maxBytes(t *testing.T) {
+	c := NewLRUCache(10, 5)
+	c.Set("a", &CacheEntry{Body: []byte("aaa")})
+	c.Set("b", &CacheEntry{Body: []byte("bb")})
+	c.Set("c", &CacheEntry{Body: []byte("c")})
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Entry exceeding the size limit was not evicted")
+	}
+	if got, want := c.Size(), int64(3); got != want {
+		t.Errorf("Size is %d, want %d", got, want)
+	}
+}
+
+func TestDo_cacheLargeBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	body := strings.Repeat("x", maxCacheBodySize+1)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=3600")
+		// Flush to send the body without a Content-Length
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, body)
+	})
+
+	req, _ := client.NewRequest("GET", ".")
+	var buf bytes.Buffer
+	if _, err := client.Do(context.Background(), req, &buf); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if buf.String() != body {
+		t.Errorf("Response body of %d bytes, want %d", buf.Len(), len(body))
+	}
+	if _, ok := client.Cache.Get(req.URL.String()); ok {
+		t.Errorf("Response larger than the cache limit was cached")
+	}
+}
@@ -0,0 +1,125 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// Value types of the parameters
+const (
+	ValueTypeSampling = "SAMPLING" // Values observed at a point in time
+	ValueTypeInterval = "INTERVAL" // Values aggregated over an interval
+)
+
+// ParameterInfo holds the metadata of a parameter
+type ParameterInfo struct {
+	ID        int
+	Title     string
+	Summary   string
+	Unit      string
+	ValueType string
+}
+
+// parameterRegistry holds the parameters known to the client, as listed by
+// version 1.0 of the API with the units it returns
+var parameterRegistry = map[int]ParameterInfo{
+	1:  {1, "Lufttemperatur", "momentanvärde, 1 gång/tim", "degree celsius", ValueTypeSampling},
+	2:  {2, "Lufttemperatur", "medelvärde 1 dygn, 1 gång/dygn, kl 00", "degree celsius", ValueTypeInterval},
+	3:  {3, "Vindriktning", "medelvärde 10 min, 1 gång/tim", "degree", ValueTypeSampling},
+	4:  {4, "Vindhastighet", "medelvärde 10 min, 1 gång/tim", "meter per second", ValueTypeSampling},
+	5:  {5, "Nederbördsmängd", "summa 1 dygn, 1 gång/dygn, kl 06", "millimeter", ValueTypeInterval},
+	6:  {6, "Relativ Luftfuktighet", "momentanvärde, 1 gång/tim", "percent", ValueTypeSampling},
+	7:  {7, "Nederbördsmängd", "summa 1 timme, 1 gång/tim", "millimeter", ValueTypeInterval},
+	8:  {8, "Snödjup", "momentanvärde, 1 gång/dygn, kl 06", "meter", ValueTypeSampling},
+	9:  {9, "Lufttryck reducerat havsytans nivå", "vid havsytans nivå, momentanvärde, 1 gång/tim", "hectopascal", ValueTypeSampling},
+	10: {10, "Solskenstid", "summa 1 timme, 1 gång/tim", "second", ValueTypeInterval},
+	11: {11, "Global Irradians (svenska stationer)", "medelvärde 1 timme, 1 gång/tim", "watt per square meter", ValueTypeInterval},
+	12: {12, "Sikt", "momentanvärde, 1 gång/tim", "meter", ValueTypeSampling},
+	13: {13, "Rådande väder", "momentanvärde, 1 gång/tim resp 8 gånger/dygn", "code", ValueTypeSampling},
+	14: {14, "Nederbördsmängd", "summa 15 min, 4 gånger/tim", "millimeter", ValueTypeInterval},
+	15: {15, "Nederbördsintensitet", "max under 15 min, 4 gånger/tim", "millimeter per second", ValueTypeInterval},
+	16: {16, "Total molnmängd", "momentanvärde, 1 gång/tim", "percent", ValueTypeSampling},
+	17: {17, "Nederbörd", "2 gånger/dygn, kl 06 och 18", "code", ValueTypeSampling},
+	18: {18, "Nederbörd", "1 gång/dygn, kl 18", "code", ValueTypeSampling},
+	19: {19, "Lufttemperatur", "min, 1 gång per dygn", "degree celsius", ValueTypeInterval},
+	20: {20, "Lufttemperatur", "max, 1 gång per dygn", "degree celsius", ValueTypeInterval},
+	21: {21, "Byvind", "max, 1 gång/tim", "meter per second", ValueTypeInterval},
+	22: {22, "Lufttemperatur", "medel, 1 gång per månad", "degree celsius", ValueTypeInterval},
+	23: {23, "Nederbördsmängd", "summa, 1 gång per månad", "millimeter", ValueTypeInterval},
+	24: {24, "Långvågs-Irradians", "Långvågsstrålning, medel 1 timme, varje timme", "watt per square meter", ValueTypeInterval},
+	25: {25, "Max av MedelVindhastighet", "maximum av medelvärde 10 min, under 3 timmar, 1 gång/tim", "meter per second", ValueTypeInterval},
+	26: {26, "Lufttemperatur", "min, 2 gånger per dygn, kl 06 och 18", "degree celsius", ValueTypeInterval},
+	27: {27, "Lufttemperatur", "max, 2 gånger per dygn, kl 06 och 18", "degree celsius", ValueTypeInterval},
+	28: {28, "Molnbas", "lägsta molnlager, momentanvärde, 1 gång/tim", "meter", ValueTypeSampling},
+	29: {29, "Molnmängd", "lägsta molnlager, momentanvärde, 1 gång/tim", "code", ValueTypeSampling},
+	30: {30, "Molnbas", "andra molnlager, momentanvärde, 1 gång/tim", "meter", ValueTypeSampling},
+	31: {31, "Molnmängd", "andra molnlager, momentanvärde, 1 gång/tim", "code", ValueTypeSampling},
+	32: {32, "Molnbas", "tredje molnlager, momentanvärde, 1 gång/tim", "meter", ValueTypeSampling},
+	33: {33, "Molnmängd", "tredje molnlager, momentanvärde, 1 gång/tim", "code", ValueTypeSampling},
+	34: {34, "Molnbas", "fjärde molnlager, momentanvärde, 1 gång/tim", "meter", ValueTypeSampling},
+	35: {35, "Molnmängd", "fjärde molnlager, momentanvärde, 1 gång/tim", "code", ValueTypeSampling},
+	36: {36, "Molnbas", "lägsta molnbas, momentanvärde, 1 gång/tim", "meter", ValueTypeSampling},
+	37: {37, "Molnbas", "lägsta molnbas, min under 15 min, 1 gång/tim", "meter", ValueTypeInterval},
+	38: {38, "Nederbördsintensitet", "max av medel under 15 min, 4 gånger/tim", "millimeter per second", ValueTypeInterval},
+	39: {39, "Daggpunktstemperatur", "momentanvärde, 1 gång/tim", "degree celsius", ValueTypeSampling},
+	40: {40, "Markens tillstånd", "momentanvärde, 1 gång/dygn, kl 06", "code", ValueTypeSampling},
+}
+
+// LookupParameter returns the metadata of a parameter known to the client
+func LookupParameter(id int) (ParameterInfo, bool) {
+	info, ok := parameterRegistry[id]
+	return info, ok
+}
+
+// KnownParameters returns the parameters known to the client ordered by ID
+func KnownParameters() []ParameterInfo {
+	infos := make([]ParameterInfo, 0, len(parameterRegistry))
+	for _, info := range parameterRegistry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+
+	return infos
+}
+
+// UnknownParameters returns the parameters not known to the client, such as
+// parameters added to the API after the client was released
+func UnknownParameters(infos []ParameterInfo) []ParameterInfo {
+	unknown := make([]ParameterInfo, 0)
+	for _, info := range infos {
+		if _, ok := parameterRegistry[info.ID]; !ok {
+			unknown = append(unknown, info)
+		}
+	}
+
+	return unknown
+}
+
+// GetParameters retrieves the catalogue of parameters of the API version of the client
+// The API does not list the value type of the parameters, which is taken from
+// the registry of known parameters when available
+func (s *ObservationService) GetParameters(ctx context.Context) ([]ParameterInfo, *http.Response, error) {
+	version, resp, err := (*APIService)(s).GetVersion(ctx, s.client.version())
+	if err != nil {
+		return nil, resp, err
+	}
+
+	infos := make([]ParameterInfo, 0, len(version.Resource))
+	for _, r := range version.Resource {
+		id, err := strconv.Atoi(r.Key)
+		if err != nil {
+			return nil, resp, fmt.Errorf("smhi: invalid parameter key %q: %w", r.Key, err)
+		}
+		info := ParameterInfo{ID: id, Title: r.Title, Summary: r.Summary, Unit: r.Unit}
+		if known, ok := parameterRegistry[id]; ok {
+			info.ValueType = known.ValueType
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+
+	return infos, resp, nil
+}
@@ -0,0 +1,91 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestObservationService_GetParameters(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, apiJSON, serverURL+baseURLPath)
+	})
+	mux.HandleFunc("/api/version/1.0.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"key": "1.0",
+			"resource": [
+				{"key": "99", "title": "Ny parameter", "summary": "momentanvärde", "unit": "lux"},
+				{"key": "1", "title": "Lufttemperatur", "summary": "momentanvärde, 1 gång/tim", "unit": "degree celsius"}
+			]
+		}`)
+	})
+
+	infos, _, err := client.Observations.GetParameters(context.Background())
+	if err != nil {
+		t.Fatalf("GetParameters returned error: %v", err)
+	}
+
+	want := []ParameterInfo{
+		{ID: 1, Title: "Lufttemperatur", Summary: "momentanvärde, 1 gång/tim", Unit: "degree celsius", ValueType: ValueTypeSampling},
+		{ID: 99, Title: "Ny parameter", Summary: "momentanvärde", Unit: "lux"},
+	}
+	if !reflect.DeepEqual(infos, want) {
+		t.Errorf("GetParameters returned %+v, want %+v", infos, want)
+	}
+
+	if got := UnknownParameters(infos); !reflect.DeepEqual(got, want[1:]) {
+		t.Errorf("UnknownParameters returned %+v, want %+v", got, want[1:])
+	}
+}
+
+func TestLookupParameter_constants(t *testing.T) {
+	constants := []int{
+		TemperatureParameterHourly, TemperatureParameterAverageDaily, TemperatureParameterMinimumDaily,
+		TemperatureParameterMaximumDaily, TemperatureParameterAverageMonthly,
+		TemperatureParameterMinimumTwiceDaily, TemperatureParameterMaximumTwiceDaily,
+		PrecipitationParameterAmountDaily, PrecipitationParameterAmountHourly, PrecipitationParameterSnowDepthDaily,
+		PrecipitationParameterTotalQuarterHourly, PrecipitationParameterIntensityQuarterHourly,
+		PrecipitationParameterTypeTwiceDaily, PrecipitationParameterTypeDaily, PrecipitationParameterAmountMonthly,
+		HumidityParameterHourly, HumidityParameterDewPointHourly, SunshineParameterAmountHourly,
+		WindParameterDirectionHourly, WindParameterSpeedHourly, WindParameterGustMaximumHourly, WindParameterMeanMaximumHourly,
+		AirPressureParameterSeaLevelHourly, VisibilityParameterHourly, PresentWeatherParameterHourly,
+		CloudParameterTotalCoverHourly, CloudParameterLowestLayerHourly, CloudParameterLowestBaseHourly,
+	}
+
+	for _, id := range constants {
+		info, ok := LookupParameter(id)
+		if !ok || info.ID != id {
+			t.Errorf("LookupParameter(%d) returned %+v, %v", id, info, ok)
+		}
+	}
+}
+
+func TestLookupParameter(t *testing.T) {
+	tests := []ParameterInfo{
+		{ID: 1, Title: "Lufttemperatur", Summary: "momentanvärde, 1 gång/tim", Unit: "degree celsius", ValueType: ValueTypeSampling},
+		{ID: 4, Title: "Vindhastighet", Summary: "medelvärde 10 min, 1 gång/tim", Unit: "meter per second", ValueType: ValueTypeSampling},
+		{ID: 7, Title: "Nederbördsmängd", Summary: "summa 1 timme, 1 gång/tim", Unit: "millimeter", ValueType: ValueTypeInterval},
+		{ID: 17, Title: "Nederbörd", Summary: "2 gånger/dygn, kl 06 och 18", Unit: "code", ValueType: ValueTypeSampling},
+	}
+	for _, want := range tests {
+		if got, ok := LookupParameter(want.ID); !ok || !reflect.DeepEqual(got, want) {
+			t.Errorf("LookupParameter(%d) returned %+v, %v, want %+v", want.ID, got, ok, want)
+		}
+	}
+
+	if _, ok := LookupParameter(99); ok {
+		t.Errorf("LookupParameter(99) found an unknown parameter")
+	}
+
+	known := KnownParameters()
+	for i := 1; i < len(known); i++ {
+		if known[i-1].ID >= known[i].ID {
+			t.Errorf("KnownParameters is not ordered by ID at %d", i)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package smhi
+
+import (
+	"context"
+	"math"
+	"net/http"
+)
+
+// Cloud parameter definitions
+const (
+	CloudParameterTotalCoverHourly  = 16
+	CloudParameterLowestLayerHourly = 28
+	CloudParameterLowestBaseHourly  = 36
+)
+
+// CloudService is a service for the cloud queries
+type CloudService service
+
+// CloudBase is a cloud base height in metres
+type CloudBase float64
+
+// CloudCover is a cloud cover in percent
+type CloudCover float64
+
+// Octas returns the cloud cover in eighths of the sky
+func (c CloudCover) Octas() int {
+	return int(math.Round(float64(c) / 12.5))
+}
+
+// CloudData holds the returned cloud data
+type CloudData struct {
+	ObservationData
+}
+
+// Bases returns the values as cloud base heights
+func (d *CloudData) Bases() ([]CloudBase, error) {
+	values, err := parseValues(d.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	bases := make([]CloudBase, len(values))
+	for i, v := range values {
+		bases[i] = CloudBase(v)
+	}
+
+	return bases, nil
+}
+
+// Covers returns the values as cloud covers
+func (d *CloudData) Covers() ([]CloudCover, error) {
+	values, err := parseValues(d.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	covers := make([]CloudCover, len(values))
+	for i, v := range values {
+		covers[i] = CloudCover(v)
+	}
+
+	return covers, nil
+}
+
+func getCloudData(ctx context.Context, s *service, parameter int, station uint32, period string, opts ...DataOption) (*CloudData, *http.Response, error) {
+	od, resp, err := getObservationData(ctx, s, parameter, station, period, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &CloudData{ObservationData: *od}, resp, nil
+}
+
+// GetHourlyTotalCovers retrieves the hourly total cloud covers from a station
+func (s *CloudService) GetHourlyTotalCovers(ctx context.Context, station uint32, period string, opts ...DataOption) (*CloudData, *http.Response, error) {
+	return getCloudData(ctx, (*service)(s), CloudParameterTotalCoverHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyTotalCovers retrieves all stations with hourly total cloud covers
+func (s *CloudService) GetStationsWithHourlyTotalCovers(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), CloudParameterTotalCoverHourly, includeInactive)
+}
+
+// GetHourlyLowestLayerBases retrieves the hourly base heights of the lowest cloud layer from a station
+func (s *CloudService) GetHourlyLowestLayerBases(ctx context.Context, station uint32, period string, opts ...DataOption) (*CloudData, *http.Response, error) {
+	return getCloudData(ctx, (*service)(s), CloudParameterLowestLayerHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyLowestLayerBases retrieves all stations with hourly base heights of the lowest cloud layer
+func (s *CloudService) GetStationsWithHourlyLowestLayerBases(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), CloudParameterLowestLayerHourly, includeInactive)
+}
+
+// GetHourlyLowestBases retrieves the hourly lowest cloud bases from a station
+func (s *CloudService) GetHourlyLowestBases(ctx context.Context, station uint32, period string, opts ...DataOption) (*CloudData, *http.Response, error) {
+	return getCloudData(ctx, (*service)(s), CloudParameterLowestBaseHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyLowestBases retrieves all stations with hourly lowest cloud bases
+func (s *CloudService) GetStationsWithHourlyLowestBases(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), CloudParameterLowestBaseHourly, includeInactive)
+}
@@ -0,0 +1,67 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCloudCover_Octas(t *testing.T) {
+	tests := []struct {
+		cover CloudCover
+		want  int
+	}{
+		{0, 0},
+		{12.5, 1},
+		{50, 4},
+		{88, 7},
+		{100, 8},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cover.Octas(); got != tt.want {
+			t.Errorf("CloudCover(%v).Octas() is %d, want %d", tt.cover, got, tt.want)
+		}
+	}
+}
+
+func TestCloudService_GetHourlyTotalCovers_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/16/station/97400/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "75", "quality": "G"}],
+		"parameter": {"key": "16", "name": "Total molnmängd", "unit": "percent"},
+		"station": {"key": "97400", "name": "Stockholm-Arlanda Flygplats", "owner": "SMHI"}}`)
+	})
+
+	clouds, _, err := client.Clouds.GetHourlyTotalCovers(context.Background(), 97400, PeriodLatestHour)
+	if err != nil {
+		t.Fatalf("Clouds.GetHourlyTotalCovers returned error: %v", err)
+	}
+
+	covers, err := clouds.Covers()
+	if err != nil {
+		t.Fatalf("CloudData.Covers returned error: %v", err)
+	}
+	if want := []CloudCover{75}; !reflect.DeepEqual(covers, want) {
+		t.Errorf("CloudData.Covers returned %v, want %v", covers, want)
+	}
+}
+
+func TestCloudService_GetHourlyLowestBases_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/36/station/12345/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Clouds.GetHourlyLowestBases(context.Background(), 12346, PeriodLatestHour)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Clouds.GetHourlyLowestBases returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
@@ -1,5 +1,26 @@
 package smhi
 
+import "time"
+
+// Timestamp is a point in time given as milliseconds since the epoch
+// Times before 1970 are given as negative values
+type Timestamp int64
+
+// NewTimestamp creates a timestamp from a time
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp(t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond))
+}
+
+// Time returns the timestamp as a time in UTC
+func (t Timestamp) Time() time.Time {
+	return time.Unix(int64(t)/1000, int64(t)%1000*int64(time.Millisecond)).UTC()
+}
+
+// IsZero reports whether the timestamp is unset
+func (t Timestamp) IsZero() bool {
+	return t == 0
+}
+
 // ParameterData holds information on the parameters
 type ParameterData struct {
 	Key     string `json:"key,omitempty"`
@@ -18,18 +39,18 @@ type StationData struct {
 
 // PeriodData holds information on the period
 type PeriodData struct {
-	Key      string `json:"key,omitempty"`
-	From     uint64 `json:"from,omitempty"`
-	To       uint64 `json:"to,omitempty"`
-	Summary  string `json:"summary,omitempty"`
-	Sampling string `json:"sampling,omitempty"`
+	Key      string    `json:"key,omitempty"`
+	From     Timestamp `json:"from,omitempty"`
+	To       Timestamp `json:"to,omitempty"`
+	Summary  string    `json:"summary,omitempty"`
+	Sampling string    `json:"sampling,omitempty"`
 }
 
 // PositionData holds information on the position
 type PositionData struct {
-	From      uint64  `json:"from,omitempty"`
-	To        uint64  `json:"to,omitempty"`
-	Height    float32 `json:"height,omitempty"`
-	Latitude  float32 `json:"latitude,omitempty"`
-	Longitude float32 `json:"longitude,omitempty"`
+	From      Timestamp `json:"from,omitempty"`
+	To        Timestamp `json:"to,omitempty"`
+	Height    float32   `json:"height,omitempty"`
+	Latitude  float32   `json:"latitude,omitempty"`
+	Longitude float32   `json:"longitude,omitempty"`
 }
@@ -0,0 +1,163 @@
+package smhi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diskCacheExt is the extension of files holding cache entries
+const diskCacheExt = ".json"
+
+// DiskCache is a cache storing responses as files in a directory, so that they
+// survive restarts of the application
+// Entries are evicted least recently used first when the total size of the
+// files exceeds the limit
+type DiskCache struct {
+	mu       sync.Mutex
+	dir      string
+	maxBytes int64
+}
+
+// diskEntry is the file format of a cache entry
+type diskEntry struct {
+	Key   string
+	Entry *CacheEntry
+}
+
+// NewDiskCache creates a cache in dir, holding up to maxBytes of responses
+// A maxBytes of zero or less disables the size limit
+func NewDiskCache(dir string, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir, maxBytes: maxBytes}, nil
+}
+
+// path returns the file of the entry for the key
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskCacheExt)
+}
+
+// Get returns the entry for the key
+func (c *DiskCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.path(key)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var de diskEntry
+	if err := json.Unmarshal(b, &de); err != nil || de.Key != key || de.Entry == nil {
+		return nil, false
+	}
+
+	// The modification time orders the entries for eviction
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return de.Entry, true
+}
+
+// Set stores the entry for the key, evicting the least recently used entries
+// when the cache is full
+func (c *DiskCache) Set(key string, entry *CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, err := json.Marshal(diskEntry{Key: key, Entry: entry})
+	if err != nil {
+		return
+	}
+
+	// Write to a temporary file first so that readers never see a partial entry
+	tmp, err := ioutil.TempFile(c.dir, "tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	c.evict()
+}
+
+// Delete removes the entry for the key
+func (c *DiskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = os.Remove(c.path(key))
+}
+
+// Size returns the total size in bytes of the entries in the cache
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var size int64
+	for _, fi := range c.files() {
+		size += fi.Size()
+	}
+	return size
+}
+
+// files returns the entry files of the cache
+func (c *DiskCache) files() []os.FileInfo {
+	infos, err := ioutil.ReadDir(c.dir)
+	if err != nil {
+		return nil
+	}
+
+	files := infos[:0]
+	for _, fi := range infos {
+		if fi.Mode().IsRegular() && strings.HasSuffix(fi.Name(), diskCacheExt) {
+			files = append(files, fi)
+		}
+	}
+	return files
+}
+
+// evict removes the least recently used entries until the cache fits its limit
+func (c *DiskCache) evict() {
+	if c.maxBytes <= 0 {
+		return
+	}
+
+	files := c.files()
+	var size int64
+	for _, fi := range files {
+		size += fi.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, fi := range files {
+		if size <= c.maxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(c.dir, fi.Name())); err == nil {
+			size -= fi.Size()
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package smhi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testDiskCache(t *testing.T, maxBytes int64) (*DiskCache, string, func()) {
+	dir, err := ioutil.TempDir("", "smhi-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewDiskCache(dir, maxBytes)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	return c, dir, func() { os.RemoveAll(dir) }
+}
+
+func TestDiskCache_persists(t *testing.T) {
+	c, dir, teardown := testDiskCache(t, 0)
+	defer teardown()
+
+	want := &CacheEntry{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": {`"v1"`}},
+		Body:       []byte(`{"Name":"test"}`),
+		ETag:       `"v1"`,
+		Expires:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	c.Set("https://example.com/a", want)
+
+	reopened, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskCache returned error: %v", err)
+	}
+	got, ok := reopened.Get("https://example.com/a")
+	if !ok {
+		t.Fatalf("Entry was not found after reopening the cache")
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Get returned %+v, want %+v", got, want)
+	}
+
+	reopened.Delete("https://example.com/a")
+	if _, ok := c.Get("https://example.com/a"); ok {
+		t.Errorf("Deleted entry was returned")
+	}
+}
+
+func TestDiskCache_evicts(t *testing.T) {
+	body := bytes.Repeat([]byte("x"), 1000)
+	c, _, teardown := testDiskCache(t, 3500)
+	defer teardown()
+
+	c.Set("a", &CacheEntry{Body: body})
+	// Make sure the entries have distinct modification times
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(c.path("a"), old, old)
+	c.Set("b", &CacheEntry{Body: body})
+	c.Set("c", &CacheEntry{Body: body})
+
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Least recently used entry was not evicted")
+	}
+	for _, key := range []string{"b", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Entry %q was evicted", key)
+		}
+	}
+	if size := c.Size(); size > 3500 {
+		t.Errorf("Size is %d, want at most 3500", size)
+	}
+}
+
+func TestDo_offline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/cached", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Name":"test"}`)
+	})
+
+	req, _ := client.NewRequest("GET", "cached")
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	client.Offline = true
+	teardown()
+
+	body := new(struct{ Name string })
+	resp, err := client.Do(context.Background(), req, body)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if body.Name != "test" {
+		t.Errorf("Response body name is %q, want %q", body.Name, "test")
+	}
+	if resp.Header.Get(CacheHeader) == "" {
+		t.Errorf("Response was not served from cache")
+	}
+
+	req, _ = client.NewRequest("GET", "missing")
+	if _, err := client.Do(context.Background(), req, nil); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Do returned error %v, want ErrCacheMiss", err)
+	}
+}
@@ -0,0 +1,187 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Forecast API definitions
+const (
+	ForecastCategory = "pmp3g"
+	ForecastVersion  = 2
+)
+
+// Forecast parameter names
+const (
+	ForecastParameterPressure              = "msl"
+	ForecastParameterTemperature           = "t"
+	ForecastParameterVisibility            = "vis"
+	ForecastParameterWindDirection         = "wd"
+	ForecastParameterWindSpeed             = "ws"
+	ForecastParameterHumidity              = "r"
+	ForecastParameterThunderProbability    = "tstm"
+	ForecastParameterTotalCloudCover       = "tcc_mean"
+	ForecastParameterLowCloudCover         = "lcc_mean"
+	ForecastParameterMediumCloudCover      = "mcc_mean"
+	ForecastParameterHighCloudCover        = "hcc_mean"
+	ForecastParameterGust                  = "gust"
+	ForecastParameterPrecipitationMin      = "pmin"
+	ForecastParameterPrecipitationMax      = "pmax"
+	ForecastParameterFrozenPrecipitation   = "spp"
+	ForecastParameterPrecipitationCategory = "pcat"
+	ForecastParameterPrecipitationMean     = "pmean"
+	ForecastParameterPrecipitationMedian   = "pmedian"
+	ForecastParameterWeatherSymbol         = "Wsymb2"
+)
+
+// ForecastService is a service for the point forecast queries
+type ForecastService service
+
+// Forecast holds a point forecast
+type Forecast struct {
+	ApprovedTime  time.Time      `json:"approvedTime,omitempty"`
+	ReferenceTime time.Time      `json:"referenceTime,omitempty"`
+	Geometry      Geometry       `json:"geometry,omitempty"`
+	TimeSeries    []ForecastTime `json:"timeSeries,omitempty"`
+}
+
+// Geometry holds the coordinates of a forecast as longitude, latitude pairs
+type Geometry struct {
+	Type        string      `json:"type,omitempty"`
+	Coordinates [][]float64 `json:"coordinates,omitempty"`
+}
+
+// ForecastTime holds the forecast parameters for a point in time
+type ForecastTime struct {
+	ValidTime  time.Time           `json:"validTime,omitempty"`
+	Parameters []ForecastParameter `json:"parameters,omitempty"`
+}
+
+// ForecastParameter holds the forecast values of a parameter
+type ForecastParameter struct {
+	Name      string    `json:"name,omitempty"`
+	LevelType string    `json:"levelType,omitempty"`
+	Level     int       `json:"level,omitempty"`
+	Unit      string    `json:"unit,omitempty"`
+	Values    []float64 `json:"values,omitempty"`
+}
+
+// ForecastValues holds the forecast parameters for a point in time as typed fields
+// Parameters missing from the forecast are NaN, or -1 for the integer fields
+type ForecastValues struct {
+	ValidTime             time.Time
+	Pressure              float64       // Air pressure at sea level in hPa
+	Temperature           float64       // Air temperature in degrees Celsius
+	Visibility            float64       // Horizontal visibility in km
+	WindDirection         float64       // Wind direction in degrees
+	WindSpeed             float64       // Wind speed in m/s
+	Humidity              float64       // Relative humidity in percent
+	ThunderProbability    float64       // Thunder probability in percent
+	TotalCloudCover       int           // Mean total cloud cover in octas
+	LowCloudCover         int           // Mean low level cloud cover in octas
+	MediumCloudCover      int           // Mean medium level cloud cover in octas
+	HighCloudCover        int           // Mean high level cloud cover in octas
+	Gust                  float64       // Wind gust speed in m/s
+	PrecipitationMin      float64       // Minimum precipitation intensity in mm/h
+	PrecipitationMax      float64       // Maximum precipitation intensity in mm/h
+	PrecipitationMean     float64       // Mean precipitation intensity in mm/h
+	PrecipitationMedian   float64       // Median precipitation intensity in mm/h
+	FrozenPrecipitation   int           // Percent of precipitation in frozen form, -9 if there is none
+	PrecipitationCategory int           // Precipitation category code
+	Symbol                WeatherSymbol // Weather symbol
+}
+
+// Value returns the first value of the named parameter
+func (t ForecastTime) Value(name string) (float64, bool) {
+	for _, p := range t.Parameters {
+		if p.Name == name && len(p.Values) > 0 {
+			return p.Values[0], true
+		}
+	}
+	return math.NaN(), false
+}
+
+// Values returns the forecast parameters as typed fields
+func (t ForecastTime) Values() ForecastValues {
+	float := func(name string) float64 {
+		v, _ := t.Value(name)
+		return v
+	}
+	integer := func(name string) int {
+		if v, ok := t.Value(name); ok {
+			return int(v)
+		}
+		return -1
+	}
+
+	return ForecastValues{
+		ValidTime:             t.ValidTime,
+		Pressure:              float(ForecastParameterPressure),
+		Temperature:           float(ForecastParameterTemperature),
+		Visibility:            float(ForecastParameterVisibility),
+		WindDirection:         float(ForecastParameterWindDirection),
+		WindSpeed:             float(ForecastParameterWindSpeed),
+		Humidity:              float(ForecastParameterHumidity),
+		ThunderProbability:    float(ForecastParameterThunderProbability),
+		TotalCloudCover:       integer(ForecastParameterTotalCloudCover),
+		LowCloudCover:         integer(ForecastParameterLowCloudCover),
+		MediumCloudCover:      integer(ForecastParameterMediumCloudCover),
+		HighCloudCover:        integer(ForecastParameterHighCloudCover),
+		Gust:                  float(ForecastParameterGust),
+		PrecipitationMin:      float(ForecastParameterPrecipitationMin),
+		PrecipitationMax:      float(ForecastParameterPrecipitationMax),
+		PrecipitationMean:     float(ForecastParameterPrecipitationMean),
+		PrecipitationMedian:   float(ForecastParameterPrecipitationMedian),
+		FrozenPrecipitation:   integer(ForecastParameterFrozenPrecipitation),
+		PrecipitationCategory: integer(ForecastParameterPrecipitationCategory),
+		Symbol:                WeatherSymbol(integer(ForecastParameterWeatherSymbol)),
+	}
+}
+
+// Values returns the forecast parameters of every point in time as typed fields
+func (f *Forecast) Values() []ForecastValues {
+	values := make([]ForecastValues, len(f.TimeSeries))
+	for i, t := range f.TimeSeries {
+		values[i] = t.Values()
+	}
+	return values
+}
+
+// formatCoordinate formats a coordinate with at most the six decimals accepted by the API
+func formatCoordinate(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+// checkCoordinates checks that the latitude and longitude are within range
+func checkCoordinates(lat, lon float64) error {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 || math.IsNaN(lon) || lon < -180 || lon > 180 {
+		return fmt.Errorf("smhi: invalid coordinates %v, %v", lat, lon)
+	}
+	return nil
+}
+
+// GetPointForecast retrieves the forecast for the point closest to the coordinates
+func (s *ForecastService) GetPointForecast(ctx context.Context, lat, lon float64) (*Forecast, *http.Response, error) {
+	if err := checkCoordinates(lat, lon); err != nil {
+		return nil, nil, err
+	}
+
+	forecastURL := fmt.Sprintf("api/category/%s/version/%d/geotype/point/lon/%s/lat/%s/data.json",
+		ForecastCategory, ForecastVersion, formatCoordinate(lon), formatCoordinate(lat))
+	req, err := (*service)(s).newRequest("GET", forecastURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := &Forecast{}
+	resp, err := s.client.Do(ctx, req, f)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return f, resp, nil
+}
@@ -0,0 +1,72 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestForecastService_GetPointForecast(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/category/pmp3g/version/2/geotype/point/lon/16.158246/lat/58.581303/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"approvedTime": "2020-05-20T10:05:34Z",
+			"referenceTime": "2020-05-20T10:00:00Z",
+			"geometry": {"type": "Point", "coordinates": [[16.158246, 58.581303]]},
+			"timeSeries": [{
+				"validTime": "2020-05-20T11:00:00Z",
+				"parameters": [
+					{"name": "msl", "levelType": "hmsl", "level": 0, "unit": "hPa", "values": [1012.3]},
+					{"name": "t", "levelType": "hl", "level": 2, "unit": "Cel", "values": [14.1]},
+					{"name": "ws", "levelType": "hl", "level": 10, "unit": "m/s", "values": [4.2]},
+					{"name": "tcc_mean", "levelType": "hl", "level": 0, "unit": "octas", "values": [6]},
+					{"name": "spp", "levelType": "hl", "level": 0, "unit": "percent", "values": [-9]},
+					{"name": "Wsymb2", "levelType": "hl", "level": 0, "unit": "category", "values": [3]}
+				]
+			}]
+		}`)
+	})
+
+	f, _, err := client.Forecasts.GetPointForecast(context.Background(), 58.5813034, 16.1582459)
+	if err != nil {
+		t.Fatalf("Forecasts.GetPointForecast returned error: %v", err)
+	}
+
+	if got, want := f.ApprovedTime, time.Date(2020, 5, 20, 10, 5, 34, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Forecast approved time is %v, want %v", got, want)
+	}
+	if got, want := f.Geometry.Coordinates, [][]float64{{16.158246, 58.581303}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Forecast coordinates are %v, want %v", got, want)
+	}
+
+	values := f.Values()
+	if len(values) != 1 {
+		t.Fatalf("Forecast has %d values, want 1", len(values))
+	}
+	v := values[0]
+	if v.Pressure != 1012.3 || v.Temperature != 14.1 || v.WindSpeed != 4.2 {
+		t.Errorf("Forecast values are %+v", v)
+	}
+	if v.TotalCloudCover != 6 || v.FrozenPrecipitation != -9 || v.Symbol != 3 {
+		t.Errorf("Forecast integer values are %+v", v)
+	}
+	if !math.IsNaN(v.Visibility) || v.LowCloudCover != -1 {
+		t.Errorf("Missing forecast values are %v and %v, want NaN and -1", v.Visibility, v.LowCloudCover)
+	}
+}
+
+func TestForecastService_GetPointForecast_invalidCoordinates(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	if _, _, err := client.Forecasts.GetPointForecast(context.Background(), 91, 16); err == nil {
+		t.Errorf("Forecasts.GetPointForecast returned no error for invalid coordinates")
+	}
+}
@@ -7,3 +7,10 @@ const (
 	PeriodLatestMonths     = "latest-months"
 	PeriodCorrectedArchive = "corrected-archive"
 )
+
+// Formats of the data, given as the type of the data links
+const (
+	FormatJSON = "application/json"
+	FormatCSV  = "text/plain"
+	FormatXML  = "application/xml"
+)
@@ -1,6 +1,39 @@
 package smhi
 
+import (
+	"context"
+	"net/http"
+)
+
 // Humidity parameter definitions
 const (
-	HumidityParameterHourly = 6 // Not implemented
+	HumidityParameterHourly         = 6
+	HumidityParameterDewPointHourly = 39
 )
+
+// HumidityService is a service for the humidity queries
+type HumidityService service
+
+// HumidityData holds the returned humidity data
+// Relative humidity is given in percent and dew point in degree celsius
+type HumidityData = ObservationData
+
+// GetHourlyRelativeHumidity retrieves the hourly relative humidity from a station
+func (s *HumidityService) GetHourlyRelativeHumidity(ctx context.Context, station uint32, period string, opts ...DataOption) (*HumidityData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), HumidityParameterHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyRelativeHumidity retrieves all stations with hourly relative humidity
+func (s *HumidityService) GetStationsWithHourlyRelativeHumidity(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), HumidityParameterHourly, includeInactive)
+}
+
+// GetHourlyDewPoints retrieves the hourly dew point temperatures from a station
+func (s *HumidityService) GetHourlyDewPoints(ctx context.Context, station uint32, period string, opts ...DataOption) (*HumidityData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), HumidityParameterDewPointHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyDewPoints retrieves all stations with hourly dew point temperatures
+func (s *HumidityService) GetStationsWithHourlyDewPoints(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), HumidityParameterDewPointHourly, includeInactive)
+}
@@ -0,0 +1,77 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHumidityService_GetHourlyDewPoints_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/39/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "12.4", "quality": "Y"}],
+		"updated": 1533466800000,
+		"parameter": {
+		"key": "39",
+		"name": "Daggpunktstemperatur",
+		"summary": "momentanvärde, 1 gång/tim",
+		"unit": "degree celsius"
+		},
+		"station": {
+		"key": "97100",
+		"name": "Tullinge A",
+		"owner": "SMHI",
+		"height": 2.0
+		}}`)
+	})
+
+	humidity, _, err := client.Humidity.GetHourlyDewPoints(context.Background(), 97100, PeriodLatestHour)
+	if err != nil {
+		t.Errorf("Humidity.GetHourlyDewPoints returned error: %v", err)
+	}
+
+	want := &HumidityData{
+		Value: []ObservationValue{
+			{
+				Date:    1533466800000,
+				Value:   "12.4",
+				Quality: "Y",
+			},
+		},
+		Updated: 1533466800000,
+		Parameter: ParameterData{
+			Key:     "39",
+			Name:    "Daggpunktstemperatur",
+			Summary: "momentanvärde, 1 gång/tim",
+			Unit:    "degree celsius",
+		},
+		Station: StationData{
+			Key:    "97100",
+			Name:   "Tullinge A",
+			Owner:  "SMHI",
+			Height: 2.0,
+		},
+	}
+	if !reflect.DeepEqual(humidity, want) {
+		t.Errorf("Humidity.GetHourlyDewPoints returned %+v, want %+v", humidity, want)
+	}
+}
+
+func TestHumidityService_GetHourlyRelativeHumidity_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/6/station/12345/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Humidity.GetHourlyRelativeHumidity(context.Background(), 12346, PeriodLatestHour)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Humidity.GetHourlyRelativeHumidity returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
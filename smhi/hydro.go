@@ -0,0 +1,60 @@
+package smhi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Hydrological parameter definitions
+const (
+	HydroParameterDischargeDaily         = 1
+	HydroParameterDischargeQuarterHourly = 2
+	HydroParameterWaterLevel             = 3
+	HydroParameterWaterTemperature       = 4
+)
+
+// HydroService is a service for the hydrological observation queries
+// The hydrological API shares the resource layout of the meteorological one
+type HydroService service
+
+// GetData retrieves the data for a parameter from a station
+func (s *HydroService) GetData(ctx context.Context, parameter int, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), parameter, station, period, opts...)
+}
+
+// GetStations retrieves all stations with data for a parameter
+func (s *HydroService) GetStations(ctx context.Context, parameter int, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), parameter, includeInactive)
+}
+
+// GetStation retrieves a station with its position history and the periods of
+// data available for a parameter
+func (s *HydroService) GetStation(ctx context.Context, parameter int, station uint32) (*Station, *http.Response, error) {
+	return getStation(ctx, (*service)(s), parameter, station)
+}
+
+// GetCorrectedArchive retrieves the corrected archive for a parameter from a station
+// The values are streamed from the response, and the reader must be closed when done
+func (s *HydroService) GetCorrectedArchive(ctx context.Context, parameter int, station uint32, opts ...DataOption) (*ArchiveReader, *http.Response, error) {
+	return getArchive(ctx, (*service)(s), parameter, station, opts...)
+}
+
+// GetDailyDischarge retrieves the daily mean water discharge from a station
+func (s *HydroService) GetDailyDischarge(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), HydroParameterDischargeDaily, station, period, opts...)
+}
+
+// GetQuarterHourlyDischarge retrieves the quarter-hourly water discharge from a station
+func (s *HydroService) GetQuarterHourlyDischarge(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), HydroParameterDischargeQuarterHourly, station, period, opts...)
+}
+
+// GetWaterLevels retrieves the water level from a station
+func (s *HydroService) GetWaterLevels(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), HydroParameterWaterLevel, station, period, opts...)
+}
+
+// GetWaterTemperatures retrieves the water temperature from a station
+func (s *HydroService) GetWaterTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), HydroParameterWaterTemperature, station, period, opts...)
+}
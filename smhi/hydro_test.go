@@ -0,0 +1,133 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+const dischargeArchive = `Stationsnamn;Stationsnummer;Stationsnät;Mäthöjd (meter över marken)
+Abiskojokk;2357;SMHIs stationsnät;0.0
+
+Parameternamn;Beskrivning;Enhet
+Vattenföring (Dygn);medelvärde 1 dygn;m3/s
+
+Datum;Vattenföring (Dygn);Kvalitet;;Tidsutsnitt:
+1990-01-01;1.52;G;;Kvalitetskontrollerade historiska data
+1990-01-02;1.48;G;;
+`
+
+func TestHydroService_GetDailyDischarge(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/hydroobs/api/version/latest/parameter/1/station/2357/period/latest-months/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"value": [{"date": 1533427200000, "value": "1.61", "quality": "Y"}],
+			"parameter": {"key": "1", "name": "Vattenföring (Dygn)", "unit": "m3/s"},
+			"station": {"key": "2357", "name": "Abiskojokk"}
+		}`)
+	})
+
+	od, _, err := client.Hydro.GetDailyDischarge(context.Background(), 2357, PeriodLatestMonths)
+	if err != nil {
+		t.Fatalf("Hydro.GetDailyDischarge returned error: %v", err)
+	}
+
+	want := &ObservationData{
+		Value:     []ObservationValue{{Date: 1533427200000, Value: "1.61", Quality: "Y"}},
+		Parameter: ParameterData{Key: "1", Name: "Vattenföring (Dygn)", Unit: "m3/s"},
+		Station:   StationData{Key: "2357", Name: "Abiskojokk"},
+	}
+	if !reflect.DeepEqual(od, want) {
+		t.Errorf("Hydro.GetDailyDischarge returned %+v, want %+v", od, want)
+	}
+}
+
+func TestHydroService_GetCorrectedArchive(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/hydroobs/api/version/latest/parameter/1/station/2357/period/corrected-archive/data.csv", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, dischargeArchive)
+	})
+
+	ar, _, err := client.Hydro.GetCorrectedArchive(context.Background(), HydroParameterDischargeDaily, 2357)
+	if err != nil {
+		t.Fatalf("Hydro.GetCorrectedArchive returned error: %v", err)
+	}
+	defer ar.Close()
+
+	if got, want := ar.Station, (StationData{Key: "2357", Name: "Abiskojokk", Owner: "SMHIs stationsnät"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ArchiveReader station is %+v, want %+v", got, want)
+	}
+
+	want := []ObservationValue{
+		{Date: 631152000000, Value: "1.52", Quality: "G"},
+		{Date: 631238400000, Value: "1.48", Quality: "G"},
+	}
+	if got := readArchive(t, ar); !reflect.DeepEqual(got, want) {
+		t.Errorf("ArchiveReader values are %+v, want %+v", got, want)
+	}
+}
+
+func TestHydroService_GetStation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	base := serverURL + baseURLPath + "/hydroobs/api/version/1.0/parameter/1/station/2357"
+	mux.HandleFunc("/hydroobs/api/version/1.0/parameter/1/station/2357.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{
+			"key": "2357",
+			"updated": 1533427200000,
+			"title": "Vattenföring (Dygn) - Abiskojokk",
+			"owner": "SMHI",
+			"active": true,
+			"position": [
+				{"from": 631152000000, "to": 1533427200000, "height": 0, "latitude": 68.3562, "longitude": 18.7822}
+			],
+			"period": [
+				{"key": "latest-months", "updated": 1533427200000, "title": "Data från senaste fyra månaderna",
+					"link": [{"rel": "period", "type": "application/json", "href": "%s/period/latest-months.json"}]}
+			]
+		}`, base)
+	})
+	mux.HandleFunc("/hydroobs/api/version/1.0/parameter/1/station/2357/period/latest-months.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"key": "latest-months", "updated": 1533427200000, "title": "Data från senaste fyra månaderna", "from": 1522540800000, "to": 1533427200000}`)
+	})
+
+	if err := WithHydroAPIVersion("1.0")(client); err != nil {
+		t.Fatalf("WithHydroAPIVersion returned error: %v", err)
+	}
+	st, _, err := client.Hydro.GetStation(context.Background(), HydroParameterDischargeDaily, 2357)
+	if err != nil {
+		t.Fatalf("Hydro.GetStation returned error: %v", err)
+	}
+
+	want := &Station{
+		Owner:   "SMHI",
+		ID:      2357,
+		Active:  true,
+		Key:     "2357",
+		Updated: 1533427200000,
+		Title:   "Vattenföring (Dygn) - Abiskojokk",
+		Position: []PositionData{
+			{From: 631152000000, To: 1533427200000, Latitude: 68.3562, Longitude: 18.7822},
+		},
+		Period: []Period{
+			{Key: "latest-months", Updated: 1533427200000, Title: "Data från senaste fyra månaderna", From: 1522540800000, To: 1533427200000},
+		},
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("Hydro.GetStation returned %+v, want %+v", st, want)
+	}
+	if got := client.APIVersion(); got != "latest" {
+		t.Errorf("APIVersion is %q after setting the hydrological version, want %q", got, "latest")
+	}
+}
@@ -0,0 +1,242 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the rate of requests
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter allowing rate requests per second,
+// with bursts of up to burst requests
+// A rate that is not positive is raised to one request per second
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if !(rate > 0) {
+		rate = 1
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// reserve takes a token and returns the time to wait before it may be used
+// No token is taken if the wait would exceed max
+func (l *RateLimiter) reserve(max time.Duration) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	var wait time.Duration
+	if l.tokens < 1 {
+		wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+	}
+	if wait > max {
+		return wait, false
+	}
+
+	l.tokens--
+	return wait, true
+}
+
+// cancel returns a token that was reserved but not used
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// Wait blocks until a request may be made or the context is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	_, err := l.wait(ctx)
+	return err
+}
+
+// wait blocks until a request may be made, returning the time it had to wait
+func (l *RateLimiter) wait(ctx context.Context) (time.Duration, error) {
+	max := time.Duration(1<<63 - 1)
+	if deadline, ok := ctx.Deadline(); ok {
+		max = time.Until(deadline)
+	}
+
+	wait, ok := l.reserve(max)
+	if !ok {
+		return 0, fmt.Errorf("smhi: rate limit wait of %v exceeds the context deadline: %w", wait, context.DeadlineExceeded)
+	}
+	if wait == 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return 0, ctx.Err()
+	case <-timer.C:
+		return wait, nil
+	}
+}
+
+// InFlightLimiter caps the number of concurrent requests
+type InFlightLimiter struct {
+	slots chan struct{}
+}
+
+// NewInFlightLimiter creates a limiter allowing max concurrent requests
+func NewInFlightLimiter(max int) *InFlightLimiter {
+	if max < 1 {
+		max = 1
+	}
+	return &InFlightLimiter{slots: make(chan struct{}, max)}
+}
+
+// Acquire blocks until a request slot is free or the context is done
+func (l *InFlightLimiter) Acquire(ctx context.Context) error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tryAcquire takes a request slot if one is free without blocking
+func (l *InFlightLimiter) tryAcquire() bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a request slot
+func (l *InFlightLimiter) Release() {
+	<-l.slots
+}
+
+// ThrottleStats holds metrics on the time requests spent waiting on the limiters
+type ThrottleStats struct {
+	Requests  int64         // Number of requests passing the limiters
+	Throttled int64         // Number of requests that had to wait
+	Wait      time.Duration // Total time spent waiting
+}
+
+type throttleStats struct {
+	mu    sync.Mutex
+	stats ThrottleStats
+}
+
+func (s *throttleStats) record(throttled bool, wait time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.stats.Requests++
+	if throttled {
+		s.stats.Throttled++
+		s.stats.Wait += wait
+	}
+}
+
+// ThrottleStats returns metrics on the time requests spent waiting on the rate
+// limiter and the in-flight limiter
+func (c *Client) ThrottleStats() ThrottleStats {
+	c.throttle.mu.Lock()
+	defer c.throttle.mu.Unlock()
+
+	return c.throttle.stats
+}
+
+// throttleRequest waits for the limiters of the client, returning a function
+// releasing the in-flight slot
+func (c *Client) throttleRequest(ctx context.Context) (func(), error) {
+	if c.RateLimiter == nil && c.InFlightLimiter == nil {
+		return func() {}, nil
+	}
+
+	start := time.Now()
+	throttled := false
+	if c.RateLimiter != nil {
+		wait, err := c.RateLimiter.wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		throttled = wait > 0
+	}
+
+	release := func() {}
+	if c.InFlightLimiter != nil {
+		if !c.InFlightLimiter.tryAcquire() {
+			throttled = true
+			if err := c.InFlightLimiter.Acquire(ctx); err != nil {
+				return nil, err
+			}
+		}
+		var once sync.Once
+		release = func() {
+			once.Do(c.InFlightLimiter.Release)
+		}
+	}
+
+	c.throttle.record(throttled, time.Since(start))
+
+	return release, nil
+}
+
+// releaseBody calls release when the body is closed
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// doThrottled executes a single attempt of the request once the limiters allow
+// it, holding the in-flight slot until the response body is closed
+func (c *Client) doThrottled(ctx context.Context, req *http.Request) (*http.Response, error) {
+	release, err := c.throttleRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
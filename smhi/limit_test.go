@@ -0,0 +1,108 @@
+package smhi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiter_invalid(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		l := NewRateLimiter(rate, 0)
+		if l.rate != 1 || l.burst != 1 {
+			t.Errorf("NewRateLimiter(%v, 0) has rate %v and burst %v, want 1 and 1", rate, l.rate, l.burst)
+		}
+		if _, err := NewClient(WithRateLimit(rate, 1)); err == nil {
+			t.Errorf("WithRateLimit(%v, 1) expected error", rate)
+		}
+	}
+}
+
+func TestDo_rateLimited(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RateLimiter = NewRateLimiter(50, 1)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		req, _ := client.NewRequest("GET", ".")
+		if _, err := client.Do(context.Background(), req, nil); err != nil {
+			t.Fatalf("Do returned error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("3 requests at 50 per second took %v, want at least 40ms", elapsed)
+	}
+
+	stats := client.ThrottleStats()
+	if stats.Requests != 3 || stats.Throttled != 2 {
+		t.Errorf("ThrottleStats is %+v, want 3 requests with 2 throttled", stats)
+	}
+	if stats.Wait <= 0 {
+		t.Errorf("ThrottleStats.Wait is %v, want time spent throttled", stats.Wait)
+	}
+}
+
+func TestRateLimiter_Wait_contextDeadline(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait returned error for the first token: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := l.Wait(ctx); err == nil {
+		t.Errorf("Wait expected error when the wait exceeds the deadline")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Millisecond {
+		t.Errorf("Wait blocked %v although the deadline could not be met", elapsed)
+	}
+}
+
+func TestDo_inFlightLimited(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.InFlightLimiter = NewInFlightLimiter(2)
+
+	var inFlight, maxInFlight int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := client.NewRequest("GET", ".")
+			if _, err := client.Do(context.Background(), req, nil); err != nil {
+				t.Errorf("Do returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("%d requests were in flight, want at most 2", got)
+	}
+	if stats := client.ThrottleStats(); stats.Throttled == 0 {
+		t.Errorf("ThrottleStats is %+v, want throttled requests", stats)
+	}
+}
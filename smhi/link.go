@@ -0,0 +1,277 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Link is a link to a related resource of the API
+type Link struct {
+	Rel  string `json:"rel,omitempty"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href,omitempty"`
+}
+
+// findLink returns the first link with the relation and type, an empty type matches any type
+func findLink(links []Link, rel, typ string) (Link, bool) {
+	for _, l := range links {
+		if l.Rel == rel && (typ == "" || l.Type == typ) {
+			return l, true
+		}
+	}
+	return Link{}, false
+}
+
+// follow retrieves the resource of the link into v, relative links are
+// resolved against the base URL of the service
+func (s *service) follow(ctx context.Context, link Link, v interface{}) (*http.Response, error) {
+	req, err := s.newRequest("GET", link.Href)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, v)
+}
+
+// followRel follows the link with the relation and type of a resource
+func (s *service) followRel(ctx context.Context, links []Link, rel, typ string, v interface{}) (*http.Response, error) {
+	link, ok := findLink(links, rel, typ)
+	if !ok {
+		return nil, fmt.Errorf("smhi: no %q link of type %q", rel, typ)
+	}
+
+	return s.follow(ctx, link, v)
+}
+
+// getPeriod retrieves a period by following its period link
+func (s *service) getPeriod(ctx context.Context, period Period) (*Period, *http.Response, error) {
+	p := &Period{}
+	resp, err := s.followRel(ctx, period.Link, "period", FormatJSON, p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return p, resp, nil
+}
+
+// Follow retrieves the resource of a link into v
+// The response body is decoded as JSON, or copied if v is an io.Writer
+func (s *ObservationService) Follow(ctx context.Context, link Link, v interface{}) (*http.Response, error) {
+	return (*service)(s).follow(ctx, link, v)
+}
+
+// GetPeriods retrieves the periods available from a station by following its station link
+func (s *ObservationService) GetPeriods(ctx context.Context, station Station) ([]Period, *http.Response, error) {
+	sr := &Station{}
+	resp, err := (*service)(s).followRel(ctx, station.Link, "station", FormatJSON, sr)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sr.Period, resp, nil
+}
+
+// GetPeriod retrieves a period with its time span and data links by following its period link
+func (s *ObservationService) GetPeriod(ctx context.Context, period Period) (*Period, *http.Response, error) {
+	return (*service)(s).getPeriod(ctx, period)
+}
+
+// dataLink returns the data link of the period in the format, retrieving the
+// period first if its data links are not known
+func (s *ObservationService) dataLink(ctx context.Context, period Period, format string) (Link, *http.Response, error) {
+	var resp *http.Response
+	if len(period.Data) == 0 {
+		p, r, err := s.GetPeriod(ctx, period)
+		if err != nil {
+			return Link{}, r, err
+		}
+		period, resp = *p, r
+	}
+
+	for _, d := range period.Data {
+		if link, ok := findLink(d.Link, "data", format); ok {
+			return link, resp, nil
+		}
+	}
+
+	return Link{}, resp, fmt.Errorf("smhi: period %q has no data of type %q", period.Key, format)
+}
+
+// GetPeriodData retrieves the data of a period by following its data link
+func (s *ObservationService) GetPeriodData(ctx context.Context, period Period, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	link, resp, err := s.dataLink(ctx, period, FormatJSON)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	od := &ObservationData{}
+	resp, err = (*service)(s).follow(ctx, link, od)
+	if err != nil {
+		return nil, resp, err
+	}
+	newDataOptions(opts).apply(od)
+
+	return od, resp, nil
+}
+
+// WritePeriodData writes the data of a period in the format, such as FormatCSV, to w
+func (s *ObservationService) WritePeriodData(ctx context.Context, period Period, format string, w io.Writer) (*http.Response, error) {
+	link, resp, err := s.dataLink(ctx, period, format)
+	if err != nil {
+		return resp, err
+	}
+
+	return (*service)(s).follow(ctx, link, w)
+}
+
+// linkCache holds the links to the resources of an API found while following
+// its links, keyed by API version and resource path
+type linkCache struct {
+	mu    sync.Mutex
+	links map[string]Link
+}
+
+func newLinkCache() *linkCache {
+	return &linkCache{links: map[string]Link{}}
+}
+
+// linkKey returns the key of a resource path within an API version
+func linkKey(version string, path []string) string {
+	return version + ":" + strings.Join(path, "/")
+}
+
+func (c *linkCache) get(key string) (Link, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	link, ok := c.links[key]
+	return link, ok
+}
+
+func (c *linkCache) set(key string, link Link) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.links[key] = link
+}
+
+// linkDocument holds the links a resource of the API lists to the resources below it
+type linkDocument struct {
+	Resource   []linkItem `json:"resource,omitempty"`
+	StationSet []linkItem `json:"stationSet,omitempty"`
+	Station    []linkItem `json:"station,omitempty"`
+	Period     []linkItem `json:"period,omitempty"`
+	Data       []linkItem `json:"data,omitempty"`
+}
+
+type linkItem struct {
+	Key  string `json:"key,omitempty"`
+	Link []Link `json:"link,omitempty"`
+}
+
+// index caches the links listed by the document of the resource at path
+// Data is keyed by its format, the other resources by their key
+func (s *service) index(version string, path []string, doc *linkDocument) {
+	child := func(elem ...string) string {
+		return linkKey(version, append(append([]string{}, path...), elem...))
+	}
+	lists := []struct {
+		name  string
+		items []linkItem
+	}{
+		{"parameter", doc.Resource},
+		{"station-set", doc.StationSet},
+		{"station", doc.Station},
+		{"period", doc.Period},
+	}
+	for _, list := range lists {
+		for _, item := range list.items {
+			for _, l := range item.Link {
+				if l.Type == FormatJSON {
+					s.links.set(child(list.name, item.Key), l)
+					break
+				}
+			}
+		}
+	}
+	for _, d := range doc.Data {
+		for _, l := range d.Link {
+			if l.Rel == "data" {
+				s.links.set(child("data", l.Type), l)
+			}
+		}
+	}
+}
+
+// versionLink returns the link to the API version, as listed by the root of the API
+func (s *service) versionLink(ctx context.Context, version string) (Link, *http.Response, error) {
+	key := linkKey(version, nil)
+	if link, ok := s.links.get(key); ok {
+		return link, nil, nil
+	}
+
+	api, resp, err := s.getAPI(ctx)
+	if err != nil {
+		return Link{}, resp, err
+	}
+	v, ok := findVersion(api.Version, version)
+	if !ok {
+		return Link{}, nil, fmt.Errorf("smhi: API version %q: %w", version, ErrNotFound)
+	}
+	link, ok := findLink(v.Link, "version", FormatJSON)
+	if !ok {
+		return Link{}, nil, fmt.Errorf("smhi: API version %q has no version link", v.Key)
+	}
+	s.links.set(key, link)
+
+	return link, resp, nil
+}
+
+// resolve returns the link to a resource of the API version used for requests,
+// such as "parameter", "1", "station", "97100", by following the links from the
+// root of the API down to it
+// Data is resolved by its format, such as "data", FormatJSON
+// The links found are cached, so the documents above a resource are only
+// retrieved the first time a resource below them is resolved
+func (s *service) resolve(ctx context.Context, path ...string) (Link, *http.Response, error) {
+	version := s.version.get()
+	if len(path) < 2 {
+		return s.versionLink(ctx, version)
+	}
+	if link, ok := s.links.get(linkKey(version, path)); ok {
+		return link, nil, nil
+	}
+
+	parentPath := path[:len(path)-2]
+	parent, resp, err := s.resolve(ctx, parentPath...)
+	if err != nil {
+		return Link{}, resp, err
+	}
+	doc := &linkDocument{}
+	resp, err = s.follow(ctx, parent, doc)
+	if err != nil {
+		return Link{}, resp, err
+	}
+	s.index(version, parentPath, doc)
+
+	link, ok := s.links.get(linkKey(version, path))
+	if !ok {
+		link = childLink(parent, path[len(path)-2], path[len(path)-1])
+	}
+	return link, resp, nil
+}
+
+// childLink returns the link to a resource not listed by its parent, such as a
+// station added after the parent was cached, addressed below the link of the parent
+func childLink(parent Link, name, key string) Link {
+	base := strings.TrimSuffix(parent.Href, ".json")
+	if name == "data" {
+		ext := map[string]string{FormatJSON: ".json", FormatCSV: ".csv", FormatXML: ".xml"}[key]
+		return Link{Rel: name, Type: key, Href: base + "/data" + ext}
+	}
+	return Link{Rel: name, Type: FormatJSON, Href: base + "/" + name + "/" + key + ".json"}
+}
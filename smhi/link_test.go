@@ -0,0 +1,150 @@
+package smhi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestObservationService_navigation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	base := serverURL + baseURLPath + "/api/version/latest/parameter/1"
+	mux.HandleFunc("/api/version/latest/parameter/1.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{
+			"key": "1",
+			"station": [{
+				"name": "Stockholm",
+				"id": 97100,
+				"active": true,
+				"key": "97100",
+				"link": [{"rel": "station", "type": "application/json", "href": "%s/station/97100.json"}]
+			}]
+		}`, base)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{
+			"key": "97100",
+			"period": [{
+				"key": "latest-day",
+				"updated": 1533495600000,
+				"title": "Data från senaste dygnet",
+				"link": [{"rel": "period", "type": "application/json", "href": "%s/station/97100/period/latest-day.json"}]
+			}]
+		}`, base)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-day.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{
+			"key": "latest-day",
+			"from": 1533409200001,
+			"to": 1533495600000,
+			"data": [{
+				"link": [
+					{"rel": "data", "type": "application/json", "href": "%[1]s/station/97100/period/latest-day/data.json"},
+					{"rel": "data", "type": "text/plain", "href": "%[1]s/station/97100/period/latest-day/data.csv"}
+				]
+			}]
+		}`, base)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"date": 1533495600000, "value": "18.2", "quality": "G"}]}`)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-day/data.csv", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Datum;Tid (UTC);Lufttemperatur;Kvalitet\n")
+	})
+
+	ctx := context.Background()
+	p, _, err := client.Observations.GetStations(ctx, 1, false)
+	if err != nil {
+		t.Fatalf("GetStations returned error: %v", err)
+	}
+
+	periods, _, err := client.Observations.GetPeriods(ctx, p.Station[0])
+	if err != nil {
+		t.Fatalf("GetPeriods returned error: %v", err)
+	}
+	if len(periods) != 1 || periods[0].Key != PeriodLatestDay {
+		t.Fatalf("GetPeriods returned %+v", periods)
+	}
+
+	period, _, err := client.Observations.GetPeriod(ctx, periods[0])
+	if err != nil {
+		t.Fatalf("GetPeriod returned error: %v", err)
+	}
+	if got, want := []Timestamp{period.From, period.To}, []Timestamp{1533409200001, 1533495600000}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetPeriod returned span %v, want %v", got, want)
+	}
+
+	// The data link of a listed period is found by retrieving the period
+	od, _, err := client.Observations.GetPeriodData(ctx, periods[0])
+	if err != nil {
+		t.Fatalf("GetPeriodData returned error: %v", err)
+	}
+	want := []ObservationValue{{Date: 1533495600000, Value: "18.2", Quality: "G"}}
+	if !reflect.DeepEqual(od.Value, want) {
+		t.Errorf("GetPeriodData returned %+v, want %+v", od.Value, want)
+	}
+
+	var buf bytes.Buffer
+	if _, err := client.Observations.WritePeriodData(ctx, *period, FormatCSV, &buf); err != nil {
+		t.Fatalf("WritePeriodData returned error: %v", err)
+	}
+	if got, want := buf.String(), "Datum;Tid (UTC);Lufttemperatur;Kvalitet\n"; got != want {
+		t.Errorf("WritePeriodData wrote %q, want %q", got, want)
+	}
+
+	if _, err := client.Observations.WritePeriodData(ctx, *period, FormatXML, &buf); err == nil {
+		t.Errorf("WritePeriodData returned no error for a missing format")
+	}
+}
+
+func TestObservationService_GetData_followsLinks(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.Cache = nil
+
+	base := serverURL + baseURLPath
+	var documents int32
+	document := func(body string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&documents, 1)
+			fmt.Fprintf(w, body, base)
+		}
+	}
+	mux.HandleFunc("/api.json", document(`{"version": [{"key": "1.0", "updated": 1,
+		"link": [{"rel": "version", "type": "application/json", "href": "%s/docs/version.json"}]}]}`))
+	mux.HandleFunc("/docs/version.json", document(`{"resource": [{"key": "1",
+		"link": [{"rel": "parameter", "type": "application/json", "href": "%s/docs/parameter.json"}]}]}`))
+	mux.HandleFunc("/docs/parameter.json", document(`{"station": [{"key": "97100",
+		"link": [{"rel": "station", "type": "application/json", "href": "%s/docs/station.json"}]}]}`))
+	mux.HandleFunc("/docs/station.json", document(`{"period": [{"key": "latest-hour",
+		"link": [{"rel": "period", "type": "application/json", "href": "%s/docs/period.json"}]}]}`))
+	mux.HandleFunc("/docs/period.json", document(`{"data": [{"key": "data",
+		"link": [{"rel": "data", "type": "application/json", "href": "%s/docs/data.json"}]}]}`))
+	mux.HandleFunc("/docs/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "21.0", "quality": "G"}]}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		od, _, err := client.Observations.GetData(context.Background(), 1, 97100, PeriodLatestHour)
+		if err != nil {
+			t.Fatalf("Observations.GetData returned error: %v", err)
+		}
+		if want := []ObservationValue{{Date: 1533466800000, Value: "21.0", Quality: "G"}}; !reflect.DeepEqual(od.Value, want) {
+			t.Errorf("Observations.GetData returned %+v, want %+v", od.Value, want)
+		}
+	}
+
+	// The documents leading to the data are only retrieved once
+	if got := atomic.LoadInt32(&documents); got != 5 {
+		t.Errorf("Observations.GetData retrieved %d documents, want 5", got)
+	}
+}
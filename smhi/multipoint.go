@@ -0,0 +1,282 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// validTimeLayout is the layout of valid times in multipoint URLs
+const validTimeLayout = "20060102T150405Z"
+
+// forecastLevel is the level a forecast parameter is given at
+type forecastLevel struct {
+	levelType string
+	level     int
+}
+
+// forecastLevels holds the levels of the forecast parameters
+var forecastLevels = map[string]forecastLevel{
+	ForecastParameterPressure:              {"hmsl", 0},
+	ForecastParameterTemperature:           {"hl", 2},
+	ForecastParameterVisibility:            {"hl", 2},
+	ForecastParameterWindDirection:         {"hl", 10},
+	ForecastParameterWindSpeed:             {"hl", 10},
+	ForecastParameterHumidity:              {"hl", 2},
+	ForecastParameterThunderProbability:    {"hl", 0},
+	ForecastParameterTotalCloudCover:       {"hl", 0},
+	ForecastParameterLowCloudCover:         {"hl", 0},
+	ForecastParameterMediumCloudCover:      {"hl", 0},
+	ForecastParameterHighCloudCover:        {"hl", 0},
+	ForecastParameterGust:                  {"hl", 10},
+	ForecastParameterPrecipitationMin:      {"hl", 0},
+	ForecastParameterPrecipitationMax:      {"hl", 0},
+	ForecastParameterFrozenPrecipitation:   {"hl", 0},
+	ForecastParameterPrecipitationCategory: {"hl", 0},
+	ForecastParameterPrecipitationMean:     {"hl", 0},
+	ForecastParameterPrecipitationMedian:   {"hl", 0},
+	ForecastParameterWeatherSymbol:         {"hl", 0},
+}
+
+// Grid is the geometry of the forecast grid
+// The points are ordered row by row, each given as a longitude, latitude pair
+type Grid struct {
+	Width       int
+	Height      int
+	Coordinates [][]float64
+}
+
+// newGrid creates a grid from the points of the multipoint geometry, starting
+// a new row where the longitude wraps back
+func newGrid(coordinates [][]float64) (*Grid, error) {
+	if len(coordinates) == 0 {
+		return nil, fmt.Errorf("smhi: forecast grid has no points")
+	}
+	for _, c := range coordinates {
+		if len(c) < 2 {
+			return nil, fmt.Errorf("smhi: forecast grid point %v is not a longitude, latitude pair", c)
+		}
+	}
+
+	width := len(coordinates)
+	for i := 1; i < len(coordinates); i++ {
+		if coordinates[i][0] < coordinates[i-1][0] {
+			width = i
+			break
+		}
+	}
+	if len(coordinates)%width != 0 {
+		return nil, fmt.Errorf("smhi: forecast grid of %d points has uneven rows of %d points", len(coordinates), width)
+	}
+
+	return &Grid{Width: width, Height: len(coordinates) / width, Coordinates: coordinates}, nil
+}
+
+// point returns the longitude and latitude of a grid point
+func (g *Grid) point(row, col int) (lon, lat float64) {
+	c := g.Coordinates[row*g.Width+col]
+	return c[0], c[1]
+}
+
+// Nearest returns the row and column of the grid point closest to the coordinates
+func (g *Grid) Nearest(lat, lon float64) (row, col int) {
+	scale := math.Cos(lat * math.Pi / 180)
+	best := math.Inf(1)
+	for i, c := range g.Coordinates {
+		dx, dy := (c[0]-lon)*scale, c[1]-lat
+		if d := dx*dx + dy*dy; d < best {
+			best, row, col = d, i/g.Width, i%g.Width
+		}
+	}
+	return row, col
+}
+
+// cell returns the cell containing the coordinates by its top left corner and
+// the position within it, each in [0, 1]
+func (g *Grid) cell(lat, lon float64) (row, col int, u, v float64, ok bool) {
+	const eps = 1e-9
+
+	nr, nc := g.Nearest(lat, lon)
+	for row := nr - 1; row <= nr; row++ {
+		for col := nc - 1; col <= nc; col++ {
+			if row < 0 || col < 0 || row+1 >= g.Height || col+1 >= g.Width {
+				continue
+			}
+			u, v, ok := g.inverseBilinear(row, col, lon, lat)
+			if ok && u >= -eps && u <= 1+eps && v >= -eps && v <= 1+eps {
+				return row, col, math.Min(math.Max(u, 0), 1), math.Min(math.Max(v, 0), 1), true
+			}
+		}
+	}
+	return 0, 0, 0, 0, false
+}
+
+// inverseBilinear finds the position of a point within the cell by Newton's method,
+// with u along the row and v across the rows
+func (g *Grid) inverseBilinear(row, col int, x, y float64) (u, v float64, ok bool) {
+	x00, y00 := g.point(row, col)
+	x01, y01 := g.point(row, col+1)
+	x10, y10 := g.point(row+1, col)
+	x11, y11 := g.point(row+1, col+1)
+
+	u, v = 0.5, 0.5
+	for i := 0; i < 20; i++ {
+		fx := (1-u)*(1-v)*x00 + u*(1-v)*x01 + (1-u)*v*x10 + u*v*x11 - x
+		fy := (1-u)*(1-v)*y00 + u*(1-v)*y01 + (1-u)*v*y10 + u*v*y11 - y
+		if math.Abs(fx) < 1e-12 && math.Abs(fy) < 1e-12 {
+			return u, v, true
+		}
+
+		dxu := (1-v)*(x01-x00) + v*(x11-x10)
+		dyu := (1-v)*(y01-y00) + v*(y11-y10)
+		dxv := (1-u)*(x10-x00) + u*(x11-x01)
+		dyv := (1-u)*(y10-y00) + u*(y11-y01)
+		det := dxu*dyv - dxv*dyu
+		if det == 0 {
+			return 0, 0, false
+		}
+		u -= (fx*dyv - fy*dxv) / det
+		v -= (fy*dxu - fx*dyu) / det
+	}
+	return u, v, true
+}
+
+// GridValues holds the values of a forecast parameter for every point of the grid
+type GridValues struct {
+	Grid          *Grid
+	ApprovedTime  time.Time
+	ReferenceTime time.Time
+	ValidTime     time.Time
+	Parameter     ForecastParameter
+}
+
+// At returns the value at a grid point
+func (g *GridValues) At(row, col int) float64 {
+	return g.Parameter.Values[row*g.Grid.Width+col]
+}
+
+// Nearest returns the value at the grid point closest to the coordinates
+func (g *GridValues) Nearest(lat, lon float64) float64 {
+	return g.At(g.Grid.Nearest(lat, lon))
+}
+
+// Bilinear returns the value at the coordinates interpolated from the four
+// surrounding grid points, and false if the coordinates are outside the grid
+func (g *GridValues) Bilinear(lat, lon float64) (float64, bool) {
+	row, col, u, v, ok := g.Grid.cell(lat, lon)
+	if !ok {
+		return math.NaN(), false
+	}
+
+	return (1-u)*(1-v)*g.At(row, col) + u*(1-v)*g.At(row, col+1) +
+		(1-u)*v*g.At(row+1, col) + u*v*g.At(row+1, col+1), true
+}
+
+// gridCache holds the forecast grid once downloaded
+type gridCache struct {
+	mu   sync.Mutex
+	grid *Grid
+}
+
+// multipointURL returns the URL of a multipoint resource
+func multipointURL(path string) string {
+	return fmt.Sprintf("api/category/%s/version/%d/geotype/multipoint%s", ForecastCategory, ForecastVersion, path)
+}
+
+// GetGrid retrieves the geometry of the forecast grid
+// The grid is downloaded once and kept by the client
+func (s *ForecastService) GetGrid(ctx context.Context) (*Grid, *http.Response, error) {
+	s.client.grid.mu.Lock()
+	defer s.client.grid.mu.Unlock()
+
+	if s.client.grid.grid != nil {
+		return s.client.grid.grid, nil, nil
+	}
+
+	req, err := (*service)(s).newRequest("GET", multipointURL(".json"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	geometry := &Geometry{}
+	resp, err := s.client.Do(ctx, req, geometry)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	grid, err := newGrid(geometry.Coordinates)
+	if err != nil {
+		return nil, resp, err
+	}
+	s.client.grid.grid = grid
+
+	return grid, resp, nil
+}
+
+// GetValidTimes retrieves the times with multipoint forecasts available
+func (s *ForecastService) GetValidTimes(ctx context.Context) ([]time.Time, *http.Response, error) {
+	req, err := (*service)(s).newRequest("GET", multipointURL("/validtime.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vt := &struct {
+		ValidTime []time.Time `json:"validTime,omitempty"`
+	}{}
+	resp, err := s.client.Do(ctx, req, vt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vt.ValidTime, resp, nil
+}
+
+// GetMultipoint retrieves the values of a forecast parameter for every point of
+// the grid at a valid time
+func (s *ForecastService) GetMultipoint(ctx context.Context, parameter string, validTime time.Time) (*GridValues, *http.Response, error) {
+	level, ok := forecastLevels[parameter]
+	if !ok {
+		return nil, nil, fmt.Errorf("smhi: unknown forecast parameter %q", parameter)
+	}
+
+	grid, resp, err := s.GetGrid(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	dataURL := multipointURL(fmt.Sprintf("/validtime/%s/parameter/%s/leveltype/%s/level/%d/data.json?with-geo=false",
+		validTime.UTC().Format(validTimeLayout), parameter, level.levelType, level.level))
+	req, err := (*service)(s).newRequest("GET", dataURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f := &Forecast{}
+	resp, err = s.client.Do(ctx, req, f)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for _, t := range f.TimeSeries {
+		for _, p := range t.Parameters {
+			if p.Name != parameter {
+				continue
+			}
+			if len(p.Values) != len(grid.Coordinates) {
+				return nil, resp, fmt.Errorf("smhi: multipoint forecast has %d values for %d grid points", len(p.Values), len(grid.Coordinates))
+			}
+			return &GridValues{
+				Grid:          grid,
+				ApprovedTime:  f.ApprovedTime,
+				ReferenceTime: f.ReferenceTime,
+				ValidTime:     t.ValidTime,
+				Parameter:     p,
+			}, resp, nil
+		}
+	}
+
+	return nil, resp, fmt.Errorf("smhi: multipoint forecast has no values for %q", parameter)
+}
@@ -0,0 +1,68 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForecastService_GetMultipoint(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var geometryCalls int32
+	mux.HandleFunc("/api/category/pmp3g/version/2/geotype/multipoint.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		atomic.AddInt32(&geometryCalls, 1)
+		fmt.Fprint(w, `{"type": "MultiPoint", "coordinates": [[10, 60], [11, 60], [12, 60], [10, 61], [11, 61], [12, 61]]}`)
+	})
+	mux.HandleFunc("/api/category/pmp3g/version/2/geotype/multipoint/validtime/20200520T110000Z/parameter/t/leveltype/hl/level/2/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if got := r.URL.Query().Get("with-geo"); got != "false" {
+			t.Errorf("Request with-geo is %q, want %q", got, "false")
+		}
+		fmt.Fprint(w, `{
+			"approvedTime": "2020-05-20T10:05:34Z",
+			"referenceTime": "2020-05-20T10:00:00Z",
+			"timeSeries": [{
+				"validTime": "2020-05-20T11:00:00Z",
+				"parameters": [{"name": "t", "levelType": "hl", "level": 2, "unit": "Cel", "values": [10, 11, 12, 20, 21, 22]}]
+			}]
+		}`)
+	})
+
+	validTime := time.Date(2020, 5, 20, 11, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		if _, _, err := client.Forecasts.GetMultipoint(context.Background(), ForecastParameterTemperature, validTime); err != nil {
+			t.Fatalf("Forecasts.GetMultipoint returned error: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&geometryCalls); got != 1 {
+		t.Errorf("Grid geometry was retrieved %d times, want 1", got)
+	}
+
+	gv, _, err := client.Forecasts.GetMultipoint(context.Background(), ForecastParameterTemperature, validTime)
+	if err != nil {
+		t.Fatalf("Forecasts.GetMultipoint returned error: %v", err)
+	}
+	if gv.Grid.Width != 3 || gv.Grid.Height != 2 {
+		t.Errorf("Grid is %dx%d, want 3x2", gv.Grid.Width, gv.Grid.Height)
+	}
+	if got, want := gv.Nearest(60.9, 11.2), 21.0; got != want {
+		t.Errorf("Nearest returned %v, want %v", got, want)
+	}
+	if got, ok := gv.Bilinear(60.5, 10.5); !ok || math.Abs(got-15.5) > 1e-9 {
+		t.Errorf("Bilinear returned %v, %v, want 15.5", got, ok)
+	}
+	if got, ok := gv.Bilinear(62, 10.5); ok {
+		t.Errorf("Bilinear outside the grid returned %v, %v", got, ok)
+	}
+
+	if _, _, err := client.Forecasts.GetMultipoint(context.Background(), "unknown", validTime); err == nil {
+		t.Errorf("Forecasts.GetMultipoint returned no error for an unknown parameter")
+	}
+}
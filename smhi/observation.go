@@ -0,0 +1,203 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ObservationService is a service for querying any of the observation parameters
+type ObservationService service
+
+// ObservationData holds the returned data for a parameter
+type ObservationData struct {
+	Value     []ObservationValue `json:"value,omitempty"`
+	Updated   Timestamp          `json:"updated,omitempty"`
+	Parameter ParameterData      `json:"parameter,omitempty"`
+	Station   StationData        `json:"station,omitempty"`
+	Period    PeriodData         `json:"period,omitempty"`
+	Position  []PositionData     `json:"position,omitempty"`
+	Link      []Link             `json:"link,omitempty"`
+}
+
+// ObservationValue holds a single observed value
+// Sampled parameters set Date, interval parameters set From, To and Ref
+type ObservationValue struct {
+	Date    Timestamp `json:"date,omitempty"`
+	From    Timestamp `json:"from,omitempty"`
+	To      Timestamp `json:"to,omitempty"`
+	Ref     string    `json:"ref,omitempty"`
+	Value   string    `json:"value,omitempty"`
+	Quality Quality   `json:"quality,omitempty"`
+}
+
+// Layouts of the reference date of interval values
+var refLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// timestamp returns the time the value is valid for
+func (v ObservationValue) timestamp() Timestamp {
+	if !v.Date.IsZero() {
+		return v.Date
+	}
+	return v.To
+}
+
+// Time returns the time the value is valid for
+// This is the date of sampled values and the end of the interval for interval values
+func (v ObservationValue) Time() time.Time {
+	return v.timestamp().Time()
+}
+
+// RefTime returns the reference date of an interval value as a time in UTC
+func (v ObservationValue) RefTime() (time.Time, error) {
+	for _, layout := range refLayouts {
+		if t, err := time.Parse(layout, v.Ref); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid reference date %q", v.Ref)
+}
+
+// Missing reports whether the value is missing
+func (v ObservationValue) Missing() bool {
+	return strings.TrimSpace(v.Value) == ""
+}
+
+// Float returns the value as a float, or NaN when the value is missing or not a number
+func (v ObservationValue) Float() float64 {
+	f, err := v.parseFloat()
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// parseFloat parses the value as a float, with missing values parsed as NaN
+func (v ObservationValue) parseFloat() (float64, error) {
+	if v.Missing() {
+		return math.NaN(), nil
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(v.Value), 64)
+	if err != nil {
+		return math.NaN(), fmt.Errorf("invalid value %q: %v", v.Value, err)
+	}
+	return f, nil
+}
+
+// parseValues parses the values as floats, with missing values parsed as NaN
+func parseValues(values []ObservationValue) ([]float64, error) {
+	parsed := make([]float64, len(values))
+	for i, v := range values {
+		f, err := v.parseFloat()
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = f
+	}
+
+	return parsed, nil
+}
+
+// stationPath returns the resource path of a station of a parameter, followed by elem
+func stationPath(parameter int, station uint32, elem ...string) []string {
+	path := []string{"parameter", strconv.Itoa(parameter), "station", strconv.FormatUint(uint64(station), 10)}
+	return append(path, elem...)
+}
+
+func getObservationData(ctx context.Context, s *service, parameter int, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	link, resp, err := s.resolve(ctx, stationPath(parameter, station, "period", period, "data", FormatJSON)...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	od := &ObservationData{}
+	resp, err = s.follow(ctx, link, od)
+	if err != nil {
+		return nil, resp, err
+	}
+	newDataOptions(opts).apply(od)
+
+	return od, resp, nil
+}
+
+func getParameterData(ctx context.Context, s *service, parameter int, includeInactive bool) (*Parameter, *http.Response, error) {
+	link, resp, err := s.resolve(ctx, "parameter", strconv.Itoa(parameter))
+	if err != nil {
+		return nil, resp, err
+	}
+
+	p := &Parameter{}
+	resp, err = s.follow(ctx, link, p)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	// Filter out the inactive stations
+	if !includeInactive {
+		newStation := make([]Station, 0)
+		for _, s := range p.Station {
+			if s.Active {
+				newStation = append(newStation, s)
+			}
+		}
+		p.Station = newStation
+	}
+
+	return p, resp, nil
+}
+
+func getStation(ctx context.Context, s *service, parameter int, station uint32) (*Station, *http.Response, error) {
+	link, resp, err := s.resolve(ctx, stationPath(parameter, station)...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	st := &Station{}
+	resp, err = s.follow(ctx, link, st)
+	if err != nil {
+		return nil, resp, err
+	}
+	if st.ID == 0 {
+		st.ID = station
+	}
+
+	// The station lists its periods without their time span, periods that fail
+	// to load are kept as listed so they can be retrieved later with GetPeriod
+	for i, p := range st.Period {
+		if !p.From.IsZero() || !p.To.IsZero() {
+			continue
+		}
+		period, _, err := s.getPeriod(ctx, p)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, resp, ctx.Err()
+			}
+			s.client.logf("smhi: period %q of station %d: %v", p.Key, station, err)
+			continue
+		}
+		st.Period[i] = *period
+	}
+
+	return st, resp, nil
+}
+
+// GetData retrieves the data for a parameter from a station
+func (s *ObservationService) GetData(ctx context.Context, parameter int, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), parameter, station, period, opts...)
+}
+
+// GetStation retrieves a station with its position history and the periods of
+// data available for a parameter, each with its time span if it could be retrieved
+func (s *ObservationService) GetStation(ctx context.Context, parameter int, station uint32) (*Station, *http.Response, error) {
+	return getStation(ctx, (*service)(s), parameter, station)
+}
+
+// GetStations retrieves all stations with data for a parameter
+func (s *ObservationService) GetStations(ctx context.Context, parameter int, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), parameter, includeInactive)
+}
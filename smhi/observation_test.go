@@ -0,0 +1,337 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestObservationService_GetData_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/4/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "3.2", "quality": "Y"}],
+		"updated": 1533466800000,
+		"parameter": {
+		"key": "4",
+		"name": "Vindhastighet",
+		"summary": "medelvärde 10 min, 1 gång/tim",
+		"unit": "meter per second"
+		},
+		"station": {
+		"key": "97100",
+		"name": "Tullinge A",
+		"owner": "SMHI",
+		"height": 10.0
+		},
+		"period": {
+		"key": "latest-hour",
+		"from": 1533463201000,
+		"to": 1533466800000,
+		"summary": "Data från senaste timmen",
+		"sampling": "1 timme"
+		},
+		"position": [
+		{
+		"from": 818985600000,
+		"to": 1533466800000,
+		"height": 45.0,
+		"latitude": 59.1789,
+		"longitude": 17.9125
+		}
+		]}`)
+	})
+
+	data, _, err := client.Observations.GetData(context.Background(), 4, 97100, PeriodLatestHour)
+	if err != nil {
+		t.Errorf("Observations.GetData returned error: %v", err)
+	}
+
+	want := &ObservationData{
+		Value: []ObservationValue{
+			{
+				Date:    1533466800000,
+				Value:   "3.2",
+				Quality: "Y",
+			},
+		},
+		Updated: 1533466800000,
+		Parameter: ParameterData{
+			Key:     "4",
+			Name:    "Vindhastighet",
+			Summary: "medelvärde 10 min, 1 gång/tim",
+			Unit:    "meter per second",
+		},
+		Station: StationData{
+			Key:    "97100",
+			Name:   "Tullinge A",
+			Owner:  "SMHI",
+			Height: 10.0,
+		},
+		Period: PeriodData{
+			Key:      "latest-hour",
+			From:     1533463201000,
+			To:       1533466800000,
+			Summary:  "Data från senaste timmen",
+			Sampling: "1 timme",
+		},
+		Position: []PositionData{
+			{
+				From:      818985600000,
+				To:        1533466800000,
+				Height:    45.0,
+				Latitude:  59.1789,
+				Longitude: 17.9125,
+			},
+		},
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Observations.GetData returned %+v, want %+v", data, want)
+	}
+}
+
+func TestObservationService_GetData_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/4/station/12345/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Observations.GetData(context.Background(), 4, 12346, PeriodLatestHour)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Observations.GetData returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
+
+func TestObservationService_GetStations_returnsOK(t *testing.T) {
+	data := `{
+		"key": "6",
+		"updated": 1533495600000,
+		"title": "Relativ Luftfuktighet: Välj station (sedan tidsutsnitt)",
+		"summary": "momentanvärde, 1 gång/tim",
+		"valueType": "SAMPLING",
+		"station": [
+		{
+			"name": "Abisko Aut",
+			"owner": "SMHI",
+			"id": 188790,
+			"height": 392.2,
+			"latitude": 68.3538,
+			"longitude": 18.8164,
+			"active": true,
+			"key": "188790",
+			"updated": 1533495600000,
+			"title": "Relativ Luftfuktighet - Abisko Aut",
+			"summary": "Latitud: 68.3538 Longitud: 18.8164 Höjd: 392.2"
+		},
+		{
+			"name": "Abisko",
+			"owner": "SMHI",
+			"id": 188800,
+			"height": 388.0,
+			"latitude": 68.3557,
+			"longitude": 18.8206,
+			"active": false,
+			"key": "188800",
+			"updated": 1533081599000,
+			"title": "Relativ Luftfuktighet - Abisko",
+			"summary": "Latitud: 68.3557 Longitud: 18.8206 Höjd: 388.0"
+		}
+		]
+	}`
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/6.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, data)
+	})
+
+	p, _, err := client.Observations.GetStations(context.Background(), 6, false)
+	if err != nil {
+		t.Errorf("Observations.GetStations returned error: %v", err)
+	}
+
+	want := &Parameter{
+		Key:       "6",
+		Updated:   1533495600000,
+		Title:     "Relativ Luftfuktighet: Välj station (sedan tidsutsnitt)",
+		Summary:   "momentanvärde, 1 gång/tim",
+		ValueType: "SAMPLING",
+		Station: []Station{
+			{
+				Name:      "Abisko Aut",
+				Owner:     "SMHI",
+				ID:        188790,
+				Height:    392.2,
+				Latitude:  68.3538,
+				Longitude: 18.8164,
+				Active:    true,
+				Key:       "188790",
+				Updated:   1533495600000,
+				Title:     "Relativ Luftfuktighet - Abisko Aut",
+				Summary:   "Latitud: 68.3538 Longitud: 18.8164 Höjd: 392.2",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Observations.GetStations returned %+v, want %+v", p, want)
+	}
+}
+
+func TestTimestamp_Time(t *testing.T) {
+	tests := []struct {
+		ts   Timestamp
+		want time.Time
+	}{
+		{1533254401000, time.Date(2018, 8, 3, 0, 0, 1, 0, time.UTC)},
+		{-283996800000, time.Date(1961, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{-6784732800000, time.Date(1755, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ts.Time(); !got.Equal(tt.want) {
+			t.Errorf("Timestamp(%d).Time() is %v, want %v", tt.ts, got, tt.want)
+		}
+		if got := NewTimestamp(tt.want); got != tt.ts {
+			t.Errorf("NewTimestamp(%v) is %d, want %d", tt.want, got, tt.ts)
+		}
+	}
+}
+
+func TestObservationValue_typedValues(t *testing.T) {
+	v := ObservationValue{From: 1533254401000, To: 1533340800000, Ref: "2018-08", Value: "21.8", Quality: "Y"}
+
+	if got := v.Float(); got != 21.8 {
+		t.Errorf("ObservationValue.Float() is %v, want 21.8", got)
+	}
+	if v.Missing() {
+		t.Errorf("ObservationValue.Missing() is true, want false")
+	}
+	if got, want := v.Time(), time.Date(2018, 8, 4, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("ObservationValue.Time() is %v, want %v", got, want)
+	}
+
+	ref, err := v.RefTime()
+	if err != nil {
+		t.Errorf("ObservationValue.RefTime() returned error: %v", err)
+	}
+	if want := time.Date(2018, 8, 1, 0, 0, 0, 0, time.UTC); !ref.Equal(want) {
+		t.Errorf("ObservationValue.RefTime() is %v, want %v", ref, want)
+	}
+
+	missing := ObservationValue{Date: 1533466800000}
+	if !missing.Missing() {
+		t.Errorf("ObservationValue.Missing() is false, want true")
+	}
+	if got := missing.Float(); !math.IsNaN(got) {
+		t.Errorf("ObservationValue.Float() is %v, want NaN", got)
+	}
+
+	values, err := parseValues([]ObservationValue{v, missing})
+	if err != nil {
+		t.Fatalf("parseValues returned error: %v", err)
+	}
+	if values[0] != 21.8 || !math.IsNaN(values[1]) {
+		t.Errorf("parseValues returned %v, want [21.8 NaN]", values)
+	}
+}
+
+func TestObservationService_GetStation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	base := serverURL + baseURLPath + "/api/version/latest/parameter/1/station/97100"
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{
+			"key": "97100",
+			"updated": 1533495600000,
+			"title": "Lufttemperatur - Stockholm",
+			"owner": "SMHI",
+			"active": true,
+			"from": -283996800000,
+			"to": 1533495600000,
+			"position": [
+				{"from": -283996800000, "to": 1533495600000, "height": 44.0, "latitude": 59.3417, "longitude": 18.0549}
+			],
+			"period": [
+				{"key": "latest-hour", "updated": 1533495600000, "title": "Data från senaste timmen",
+					"link": [{"rel": "period", "type": "application/json", "href": "%s/period/latest-hour.json"}]}
+			]
+		}`, base)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-hour.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"key": "latest-hour", "updated": 1533495600000, "title": "Data från senaste timmen", "from": 1533492000001, "to": 1533495600000}`)
+	})
+
+	st, _, err := client.Observations.GetStation(context.Background(), 1, 97100)
+	if err != nil {
+		t.Fatalf("Observations.GetStation returned error: %v", err)
+	}
+
+	want := &Station{
+		Owner:   "SMHI",
+		ID:      97100,
+		Active:  true,
+		Key:     "97100",
+		Updated: 1533495600000,
+		Title:   "Lufttemperatur - Stockholm",
+		From:    -283996800000,
+		To:      1533495600000,
+		Position: []PositionData{
+			{From: -283996800000, To: 1533495600000, Height: 44.0, Latitude: 59.3417, Longitude: 18.0549},
+		},
+		Period: []Period{
+			{Key: "latest-hour", Updated: 1533495600000, Title: "Data från senaste timmen", From: 1533492000001, To: 1533495600000},
+		},
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("Observations.GetStation returned %+v, want %+v", st, want)
+	}
+}
+
+func TestObservationService_GetStation_periodFails(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	base := serverURL + baseURLPath + "/api/version/latest/parameter/1/station/97100"
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{
+			"key": "97100",
+			"period": [
+				{"key": "latest-hour", "link": [{"rel": "period", "type": "application/json", "href": "%[1]s/period/latest-hour.json"}]},
+				{"key": "latest-day", "link": [{"rel": "period", "type": "application/json", "href": "%[1]s/period/latest-day.json"}]}
+			]
+		}`, base)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-hour.json", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusNotFound)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-day.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"key": "latest-day", "from": 1533409200001, "to": 1533495600000}`)
+	})
+
+	st, _, err := client.Observations.GetStation(context.Background(), 1, 97100)
+	if err != nil {
+		t.Fatalf("Observations.GetStation returned error: %v", err)
+	}
+
+	want := []Period{
+		{Key: "latest-hour", Link: []Link{{Rel: "period", Type: "application/json", Href: base + "/period/latest-hour.json"}}},
+		{Key: "latest-day", From: 1533409200001, To: 1533495600000},
+	}
+	if !reflect.DeepEqual(st.Period, want) {
+		t.Errorf("Observations.GetStation returned periods %+v, want %+v", st.Period, want)
+	}
+}
@@ -0,0 +1,66 @@
+package smhi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Ocean parameter definitions
+const (
+	OceanParameterWaveHeight       = 1
+	OceanParameterCurrentDirection = 2
+	OceanParameterCurrentSpeed     = 3
+	OceanParameterSalinity         = 4
+	OceanParameterSeaTemperature   = 5
+	OceanParameterSeaLevel         = 6
+)
+
+// OceanService is a service for the oceanographic observation queries
+// The oceanographic API shares the resource layout of the meteorological one
+type OceanService service
+
+// GetData retrieves the data for a parameter from a station
+func (s *OceanService) GetData(ctx context.Context, parameter int, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), parameter, station, period, opts...)
+}
+
+// GetStations retrieves all stations with data for a parameter
+func (s *OceanService) GetStations(ctx context.Context, parameter int, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), parameter, includeInactive)
+}
+
+// GetStation retrieves a station with its position history and the periods of
+// data available for a parameter
+func (s *OceanService) GetStation(ctx context.Context, parameter int, station uint32) (*Station, *http.Response, error) {
+	return getStation(ctx, (*service)(s), parameter, station)
+}
+
+// GetSeaLevels retrieves the sea level from a station
+func (s *OceanService) GetSeaLevels(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), OceanParameterSeaLevel, station, period, opts...)
+}
+
+// GetSeaTemperatures retrieves the sea temperature from a station
+func (s *OceanService) GetSeaTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), OceanParameterSeaTemperature, station, period, opts...)
+}
+
+// GetSalinity retrieves the salinity from a station
+func (s *OceanService) GetSalinity(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), OceanParameterSalinity, station, period, opts...)
+}
+
+// GetWaveHeights retrieves the significant wave height from a station
+func (s *OceanService) GetWaveHeights(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), OceanParameterWaveHeight, station, period, opts...)
+}
+
+// GetCurrentSpeeds retrieves the current speed from a station
+func (s *OceanService) GetCurrentSpeeds(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), OceanParameterCurrentSpeed, station, period, opts...)
+}
+
+// GetCurrentDirections retrieves the current direction from a station
+func (s *OceanService) GetCurrentDirections(ctx context.Context, station uint32, period string, opts ...DataOption) (*ObservationData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), OceanParameterCurrentDirection, station, period, opts...)
+}
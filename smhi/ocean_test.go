@@ -0,0 +1,185 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestOceanService_GetSeaLevels(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/6/station/2130/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"value": [
+				{"date": 1533492000000, "value": "12.0", "quality": "G"},
+				{"date": 1533495600000, "value": "-3.5", "quality": "Y"}
+			],
+			"updated": 1533495600000,
+			"parameter": {"key": "6", "name": "Havsvattenstånd", "summary": "momentanvärde, 1 gång/tim", "unit": "cm"},
+			"station": {"key": "2130", "name": "Stockholm", "owner": "SMHI", "height": 0}
+		}`)
+	})
+
+	od, _, err := client.Ocean.GetSeaLevels(context.Background(), 2130, PeriodLatestDay, OnlyControlled())
+	if err != nil {
+		t.Fatalf("Ocean.GetSeaLevels returned error: %v", err)
+	}
+
+	want := &ObservationData{
+		Value:     []ObservationValue{{Date: 1533492000000, Value: "12.0", Quality: "G"}},
+		Updated:   1533495600000,
+		Parameter: ParameterData{Key: "6", Name: "Havsvattenstånd", Summary: "momentanvärde, 1 gång/tim", Unit: "cm"},
+		Station:   StationData{Key: "2130", Name: "Stockholm", Owner: "SMHI"},
+	}
+	if !reflect.DeepEqual(od, want) {
+		t.Errorf("Ocean.GetSeaLevels returned %+v, want %+v", od, want)
+	}
+}
+
+func TestOceanService_GetStations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/5.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"key": "5",
+			"title": "Havstemperatur",
+			"station": [
+				{"name": "Landsort", "id": 2507, "active": true, "key": "2507"},
+				{"name": "Kungsholmsfort", "id": 2069, "active": false, "key": "2069"}
+			]
+		}`)
+	})
+
+	p, _, err := client.Ocean.GetStations(context.Background(), OceanParameterSeaTemperature, false)
+	if err != nil {
+		t.Fatalf("Ocean.GetStations returned error: %v", err)
+	}
+
+	want := &Parameter{
+		Key:     "5",
+		Title:   "Havstemperatur",
+		Station: []Station{{Name: "Landsort", ID: 2507, Active: true, Key: "2507"}},
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Ocean.GetStations returned %+v, want %+v", p, want)
+	}
+}
+
+func TestOceanService_GetStation(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	base := serverURL + baseURLPath + "/ocobs/api/version/latest/parameter/6/station/2130"
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/6/station/2130.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprintf(w, `{
+			"key": "2130",
+			"updated": 1533495600000,
+			"title": "Havsvattenstånd - Stockholm",
+			"owner": "SMHI",
+			"active": true,
+			"position": [
+				{"from": -283996800000, "to": 1533495600000, "height": 0, "latitude": 59.3242, "longitude": 18.0814}
+			],
+			"period": [
+				{"key": "latest-day", "updated": 1533495600000, "title": "Data från senaste dygnet",
+					"link": [{"rel": "period", "type": "application/json", "href": "%s/period/latest-day.json"}]}
+			]
+		}`, base)
+	})
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/6/station/2130/period/latest-day.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"key": "latest-day", "updated": 1533495600000, "title": "Data från senaste dygnet", "from": 1533409200001, "to": 1533495600000}`)
+	})
+
+	st, _, err := client.Ocean.GetStation(context.Background(), OceanParameterSeaLevel, 2130)
+	if err != nil {
+		t.Fatalf("Ocean.GetStation returned error: %v", err)
+	}
+
+	want := &Station{
+		Owner:   "SMHI",
+		ID:      2130,
+		Active:  true,
+		Key:     "2130",
+		Updated: 1533495600000,
+		Title:   "Havsvattenstånd - Stockholm",
+		Position: []PositionData{
+			{From: -283996800000, To: 1533495600000, Latitude: 59.3242, Longitude: 18.0814},
+		},
+		Period: []Period{
+			{Key: "latest-day", Updated: 1533495600000, Title: "Data från senaste dygnet", From: 1533409200001, To: 1533495600000},
+		},
+	}
+	if !reflect.DeepEqual(st, want) {
+		t.Errorf("Ocean.GetStation returned %+v, want %+v", st, want)
+	}
+}
+
+func TestOceanService_version(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/6/station/2130/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"updated": 1533495600000}`)
+	})
+	mux.HandleFunc("/ocobs/api/version/1.0/parameter/6/station/2130/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"updated": 1533409200000}`)
+	})
+
+	// Pinning the meteorological API leaves the ocean API unpinned
+	client.apiVersion.set("1.0")
+	od, _, err := client.Ocean.GetSeaLevels(context.Background(), 2130, PeriodLatestDay)
+	if err != nil {
+		t.Fatalf("Ocean.GetSeaLevels returned error: %v", err)
+	}
+	if od.Updated != 1533495600000 {
+		t.Errorf("Ocean.GetSeaLevels used the meteorological API version")
+	}
+
+	if err := WithOceanAPIVersion("1.0")(client); err != nil {
+		t.Fatalf("WithOceanAPIVersion returned error: %v", err)
+	}
+	od, _, err = client.Ocean.GetSeaLevels(context.Background(), 2130, PeriodLatestDay)
+	if err != nil {
+		t.Fatalf("Ocean.GetSeaLevels returned error: %v", err)
+	}
+	if od.Updated != 1533409200000 {
+		t.Errorf("Ocean.GetSeaLevels did not use the ocean API version")
+	}
+}
+
+func TestOceanService_GetStation_relativeLinks(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/6/station/2130.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{
+			"key": "2130",
+			"period": [
+				{"key": "latest-day", "link": [{"rel": "period", "type": "application/json", "href": "api/version/latest/parameter/6/station/2130/period/latest-day.json"}]}
+			]
+		}`)
+	})
+	mux.HandleFunc("/ocobs/api/version/latest/parameter/6/station/2130/period/latest-day.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"key": "latest-day", "from": 1533409200001, "to": 1533495600000}`)
+	})
+
+	st, _, err := client.Ocean.GetStation(context.Background(), OceanParameterSeaLevel, 2130)
+	if err != nil {
+		t.Fatalf("Ocean.GetStation returned error: %v", err)
+	}
+
+	want := []Period{{Key: "latest-day", From: 1533409200001, To: 1533495600000}}
+	if !reflect.DeepEqual(st.Period, want) {
+		t.Errorf("Ocean.GetStation returned periods %+v, want %+v", st.Period, want)
+	}
+}
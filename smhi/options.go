@@ -0,0 +1,218 @@
+package smhi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a client created by NewClient
+type Option func(*Client) error
+
+// Logger logs the activity of the client, such as retried requests
+// *log.Logger implements it
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) error {
+		if httpClient == nil {
+			return errors.New("smhi: HTTP client is nil")
+		}
+		c.client = httpClient
+		return nil
+	}
+}
+
+// parseBaseURL parses and validates a base URL
+func parseBaseURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("smhi: invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("smhi: base URL %q must be an absolute http or https URL", rawURL)
+	}
+	// Relative paths resolve against the base URL only with a trailing slash
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return u, nil
+}
+
+// WithBaseURL sets the base URL of the observation API
+func WithBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.BaseURL = u
+		return nil
+	}
+}
+
+// WithForecastBaseURL sets the base URL of the forecast API
+func WithForecastBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.forecastURL = u
+		return nil
+	}
+}
+
+// WithOceanBaseURL sets the base URL of the oceanographic observation API
+func WithOceanBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.oceanURL = u
+		return nil
+	}
+}
+
+// WithHydroBaseURL sets the base URL of the hydrological observation API
+func WithHydroBaseURL(rawURL string) Option {
+	return func(c *Client) error {
+		u, err := parseBaseURL(rawURL)
+		if err != nil {
+			return err
+		}
+		c.hydroURL = u
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		if userAgent == "" {
+			return errors.New("smhi: user agent is empty")
+		}
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit of requests, including reading the response body
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return fmt.Errorf("smhi: timeout %v must be positive", timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithRetryPolicy sets the retry policy, nil disables retries
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(c *Client) error {
+		if policy != nil {
+			if policy.MaxAttempts < 1 {
+				return fmt.Errorf("smhi: retry policy max attempts %d must be at least 1", policy.MaxAttempts)
+			}
+			if policy.MinBackoff < 0 || policy.MaxBackoff < 0 || policy.MinBackoff > policy.MaxBackoff {
+				return fmt.Errorf("smhi: retry policy backoff %v-%v is invalid", policy.MinBackoff, policy.MaxBackoff)
+			}
+		}
+		c.RetryPolicy = policy
+		return nil
+	}
+}
+
+// WithCache sets the response cache, nil disables caching
+func WithCache(cache Cache) Option {
+	return func(c *Client) error {
+		c.Cache = cache
+		return nil
+	}
+}
+
+// WithRateLimit limits requests to rate per second, with bursts of up to burst requests
+func WithRateLimit(rate float64, burst int) Option {
+	return func(c *Client) error {
+		if !(rate > 0) {
+			return fmt.Errorf("smhi: rate limit %v must be positive", rate)
+		}
+		if burst < 1 {
+			return fmt.Errorf("smhi: rate limit burst %d must be at least 1", burst)
+		}
+		c.RateLimiter = NewRateLimiter(rate, burst)
+		return nil
+	}
+}
+
+// WithMaxInFlight caps the number of concurrent requests
+func WithMaxInFlight(max int) Option {
+	return func(c *Client) error {
+		if max < 1 {
+			return fmt.Errorf("smhi: max in-flight requests %d must be at least 1", max)
+		}
+		c.InFlightLimiter = NewInFlightLimiter(max)
+		return nil
+	}
+}
+
+// WithLogger sets the logger of the client
+func WithLogger(logger Logger) Option {
+	return func(c *Client) error {
+		if logger == nil {
+			return errors.New("smhi: logger is nil")
+		}
+		c.Logger = logger
+		return nil
+	}
+}
+
+// checkAPIVersion checks that the version can be used in the resource paths
+func checkAPIVersion(version string) error {
+	if version == "" || strings.ContainsAny(version, "/?#") {
+		return fmt.Errorf("smhi: invalid API version %q", version)
+	}
+	return nil
+}
+
+// WithAPIVersion sets the version of the meteorological observation API used
+// for requests, such as "1.0"
+func WithAPIVersion(version string) Option {
+	return func(c *Client) error {
+		if err := checkAPIVersion(version); err != nil {
+			return err
+		}
+		c.apiVersion.set(version)
+		return nil
+	}
+}
+
+// WithOceanAPIVersion sets the version of the oceanographic observation API used for requests
+func WithOceanAPIVersion(version string) Option {
+	return func(c *Client) error {
+		if err := checkAPIVersion(version); err != nil {
+			return err
+		}
+		c.oceanVersion.set(version)
+		return nil
+	}
+}
+
+// WithHydroAPIVersion sets the version of the hydrological observation API used for requests
+func WithHydroAPIVersion(version string) Option {
+	return func(c *Client) error {
+		if err := checkAPIVersion(version); err != nil {
+			return err
+		}
+		c.hydroVersion.set(version)
+		return nil
+	}
+}
@@ -3,29 +3,66 @@ package smhi
 // Parameter holds information on all datapoints for that parameter
 // Parameter is a type of weather observation
 type Parameter struct {
-	Key        string     `json:"key,omitempty"`
-	Updated    uint64     `json:"updated,omitempty"`
-	Title      string     `json:"title,omitempty"`
-	Summary    string     `json:"summary,omitempty"`
-	ValueType  string     `json:"valueType,omitempty"`
-	StationSet StationSet `json:"stationSet,omitempty"`
-	Station    []Station  `json:"station,omitempty"`
+	Key        string       `json:"key,omitempty"`
+	Updated    Timestamp    `json:"updated,omitempty"`
+	Title      string       `json:"title,omitempty"`
+	Summary    string       `json:"summary,omitempty"`
+	ValueType  string       `json:"valueType,omitempty"`
+	StationSet []StationSet `json:"stationSet,omitempty"`
+	Station    []Station    `json:"station,omitempty"`
+	Link       []Link       `json:"link,omitempty"`
 }
 
-// StationSet is a set of stations
-type StationSet struct{}
+// StationSet is a set of stations with data available in one request
+type StationSet struct {
+	Key     string    `json:"key,omitempty"`
+	Updated Timestamp `json:"updated,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	Summary string    `json:"summary,omitempty"`
+	Link    []Link    `json:"link,omitempty"`
+}
 
 // Station defines a measurment station
 type Station struct {
-	Name      string  `json:"name,omitempty"`
-	Owner     string  `json:"owner,omitempty"`
-	ID        uint32  `json:"id,omitempty"`
-	Height    float32 `json:"height,omitempty"`
-	Latitude  float32 `json:"latitude,omitempty"`
-	Longitude float32 `json:"longitude,omitempty"`
-	Active    bool    `json:"active,omitempty"`
-	Key       string  `json:"key,omitempty"`
-	Updated   uint64  `json:"updated,omitempty"`
-	Title     string  `json:"title,omitempty"`
-	Summary   string  `json:"summary,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Owner     string    `json:"owner,omitempty"`
+	ID        uint32    `json:"id,omitempty"`
+	Height    float32   `json:"height,omitempty"`
+	Latitude  float32   `json:"latitude,omitempty"`
+	Longitude float32   `json:"longitude,omitempty"`
+	Active    bool      `json:"active,omitempty"`
+	Key       string    `json:"key,omitempty"`
+	Updated   Timestamp `json:"updated,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Summary   string    `json:"summary,omitempty"`
+	Link      []Link    `json:"link,omitempty"`
+
+	// Set on stations retrieved with ObservationService.GetStation
+	From     Timestamp      `json:"from,omitempty"`
+	To       Timestamp      `json:"to,omitempty"`
+	Position []PositionData `json:"position,omitempty"`
+	Period   []Period       `json:"period,omitempty"`
+}
+
+// Period is a period of data available from a station
+// Periods listed for a station only have their From, To and Data set once
+// retrieved with ObservationService.GetPeriod
+type Period struct {
+	Key     string         `json:"key,omitempty"`
+	Updated Timestamp      `json:"updated,omitempty"`
+	Title   string         `json:"title,omitempty"`
+	Summary string         `json:"summary,omitempty"`
+	From    Timestamp      `json:"from,omitempty"`
+	To      Timestamp      `json:"to,omitempty"`
+	Link    []Link         `json:"link,omitempty"`
+	Data    []DataResource `json:"data,omitempty"`
+}
+
+// DataResource holds the links to the data of a period in the available formats
+type DataResource struct {
+	Key     string    `json:"key,omitempty"`
+	Updated Timestamp `json:"updated,omitempty"`
+	Title   string    `json:"title,omitempty"`
+	Summary string    `json:"summary,omitempty"`
+	Link    []Link    `json:"link,omitempty"`
 }
@@ -1,13 +1,256 @@
 package smhi
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
 // Precipitation parameter definitions
 const (
-	PrecipitationParameterAmountDaily            = 5  // Not implemented
-	PrecipitationParameterAmountHourly           = 7  // Not implemented
-	PrecipitationParameterSnowDepthDaily         = 8  // Not implemented
-	PrecipitationParameterTotalQuarterHourly     = 14 // Not implemented
-	PrecipitationParameterIntensityQuarterHourly = 15 // Not implemented
-	PrecipitationParameterAmountTwiceDaily       = 17 // Not implemented
-	PrecipitationParameterIntensityDaily         = 18 // Not implemented
-	PrecipitationParameterAmountMonthly          = 23 // Not implemented
+	PrecipitationParameterAmountDaily            = 5
+	PrecipitationParameterAmountHourly           = 7
+	PrecipitationParameterSnowDepthDaily         = 8
+	PrecipitationParameterTotalQuarterHourly     = 14
+	PrecipitationParameterIntensityQuarterHourly = 15
+	PrecipitationParameterTypeTwiceDaily         = 17
+	PrecipitationParameterTypeDaily              = 18
+	PrecipitationParameterAmountMonthly          = 23
+
+	// Deprecated: parameter 17 is the precipitation type, use PrecipitationParameterTypeTwiceDaily
+	PrecipitationParameterAmountTwiceDaily = PrecipitationParameterTypeTwiceDaily
+	// Deprecated: parameter 18 is the precipitation type, use PrecipitationParameterTypeDaily
+	PrecipitationParameterIntensityDaily = PrecipitationParameterTypeDaily
+)
+
+// PrecipitationService is a service for the precipitation queries
+type PrecipitationService service
+
+// PrecipitationData holds the returned precipitation data
+type PrecipitationData struct {
+	ObservationData
+}
+
+// PrecipitationAmount is an amount of precipitation in millimetres
+type PrecipitationAmount float64
+
+// PrecipitationIntensity is a precipitation intensity in millimetres per hour
+// SMHI reports intensities in millimetres per second, they are converted by
+// PrecipitationData.Intensities
+type PrecipitationIntensity float64
+
+// Units of the precipitation intensities
+const (
+	unitMillimeterPerSecond = "millimeter per second"
+	unitMillimeterPerHour   = "millimeter per hour"
+)
+
+// SnowDepth is a snow depth in metres
+type SnowDepth float64
+
+type precipitationKind int
+
+const (
+	precipitationKindUnknown precipitationKind = iota
+	precipitationKindAmount
+	precipitationKindIntensity
+	precipitationKindSnowDepth
+	precipitationKindType
 )
+
+func (k precipitationKind) String() string {
+	switch k {
+	case precipitationKindAmount:
+		return "amount"
+	case precipitationKindIntensity:
+		return "intensity"
+	case precipitationKindSnowDepth:
+		return "snow depth"
+	case precipitationKindType:
+		return "type"
+	}
+	return "unknown"
+}
+
+func precipitationKindOf(parameter int) precipitationKind {
+	switch parameter {
+	case PrecipitationParameterAmountDaily,
+		PrecipitationParameterAmountHourly,
+		PrecipitationParameterTotalQuarterHourly,
+		PrecipitationParameterAmountMonthly:
+		return precipitationKindAmount
+	case PrecipitationParameterIntensityQuarterHourly:
+		return precipitationKindIntensity
+	case PrecipitationParameterSnowDepthDaily:
+		return precipitationKindSnowDepth
+	case PrecipitationParameterTypeTwiceDaily,
+		PrecipitationParameterTypeDaily:
+		return precipitationKindType
+	}
+	return precipitationKindUnknown
+}
+
+// values parses the values after checking that the data is of the wanted kind
+func (d *PrecipitationData) values(want precipitationKind) ([]float64, error) {
+	parameter, err := strconv.Atoi(d.Parameter.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter key %q: %v", d.Parameter.Key, err)
+	}
+	if got := precipitationKindOf(parameter); got != want {
+		return nil, fmt.Errorf("parameter %d is a precipitation %s, not a precipitation %s", parameter, got, want)
+	}
+
+	return parseValues(d.Value)
+}
+
+// Amounts returns the values as precipitation amounts
+func (d *PrecipitationData) Amounts() ([]PrecipitationAmount, error) {
+	values, err := d.values(precipitationKindAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts := make([]PrecipitationAmount, len(values))
+	for i, v := range values {
+		amounts[i] = PrecipitationAmount(v)
+	}
+
+	return amounts, nil
+}
+
+// Intensities returns the values as precipitation intensities in millimetres per hour
+// The values are converted from the unit of the parameter, or the unit of the
+// parameter registry if the data has none
+func (d *PrecipitationData) Intensities() ([]PrecipitationIntensity, error) {
+	values, err := d.values(precipitationKindIntensity)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := d.Parameter.Unit
+	if unit == "" {
+		parameter, _ := strconv.Atoi(d.Parameter.Key)
+		unit = parameterRegistry[parameter].Unit
+	}
+	var scale float64
+	switch unit {
+	case unitMillimeterPerSecond:
+		scale = 3600
+	case unitMillimeterPerHour:
+		scale = 1
+	default:
+		return nil, fmt.Errorf("unknown precipitation intensity unit %q", unit)
+	}
+
+	intensities := make([]PrecipitationIntensity, len(values))
+	for i, v := range values {
+		intensities[i] = PrecipitationIntensity(v * scale)
+	}
+
+	return intensities, nil
+}
+
+// SnowDepths returns the values as snow depths
+func (d *PrecipitationData) SnowDepths() ([]SnowDepth, error) {
+	values, err := d.values(precipitationKindSnowDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	depths := make([]SnowDepth, len(values))
+	for i, v := range values {
+		depths[i] = SnowDepth(v)
+	}
+
+	return depths, nil
+}
+
+func getPrecipitationData(ctx context.Context, s *service, parameter int, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	od, resp, err := getObservationData(ctx, s, parameter, station, period, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &PrecipitationData{ObservationData: *od}, resp, nil
+}
+
+// GetDailyAmounts retrieves the daily precipitation amounts from a station
+func (s *PrecipitationService) GetDailyAmounts(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterAmountDaily, station, period, opts...)
+}
+
+// GetStationsWithDailyAmounts retrieves all stations with daily precipitation amounts
+func (s *PrecipitationService) GetStationsWithDailyAmounts(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterAmountDaily, includeInactive)
+}
+
+// GetHourlyAmounts retrieves the hourly precipitation amounts from a station
+func (s *PrecipitationService) GetHourlyAmounts(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterAmountHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyAmounts retrieves all stations with hourly precipitation amounts
+func (s *PrecipitationService) GetStationsWithHourlyAmounts(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterAmountHourly, includeInactive)
+}
+
+// GetDailySnowDepths retrieves the daily snow depths from a station
+func (s *PrecipitationService) GetDailySnowDepths(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterSnowDepthDaily, station, period, opts...)
+}
+
+// GetStationsWithDailySnowDepths retrieves all stations with daily snow depths
+func (s *PrecipitationService) GetStationsWithDailySnowDepths(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterSnowDepthDaily, includeInactive)
+}
+
+// GetQuarterHourlyTotals retrieves the quarter-hourly precipitation totals from a station
+func (s *PrecipitationService) GetQuarterHourlyTotals(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterTotalQuarterHourly, station, period, opts...)
+}
+
+// GetStationsWithQuarterHourlyTotals retrieves all stations with quarter-hourly precipitation totals
+func (s *PrecipitationService) GetStationsWithQuarterHourlyTotals(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterTotalQuarterHourly, includeInactive)
+}
+
+// GetQuarterHourlyIntensities retrieves the quarter-hourly precipitation intensities from a station
+func (s *PrecipitationService) GetQuarterHourlyIntensities(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterIntensityQuarterHourly, station, period, opts...)
+}
+
+// GetStationsWithQuarterHourlyIntensities retrieves all stations with quarter-hourly precipitation intensities
+func (s *PrecipitationService) GetStationsWithQuarterHourlyIntensities(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterIntensityQuarterHourly, includeInactive)
+}
+
+// GetTwiceDailyTypes retrieves the precipitation type codes observed twice daily from a station
+func (s *PrecipitationService) GetTwiceDailyTypes(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterTypeTwiceDaily, station, period, opts...)
+}
+
+// GetStationsWithTwiceDailyTypes retrieves all stations with precipitation types observed twice daily
+func (s *PrecipitationService) GetStationsWithTwiceDailyTypes(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterTypeTwiceDaily, includeInactive)
+}
+
+// GetDailyTypes retrieves the precipitation type codes observed daily from a station
+func (s *PrecipitationService) GetDailyTypes(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterTypeDaily, station, period, opts...)
+}
+
+// GetStationsWithDailyTypes retrieves all stations with precipitation types observed daily
+func (s *PrecipitationService) GetStationsWithDailyTypes(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterTypeDaily, includeInactive)
+}
+
+// GetMonthlyAmounts retrieves the monthly precipitation amounts from a station
+func (s *PrecipitationService) GetMonthlyAmounts(ctx context.Context, station uint32, period string, opts ...DataOption) (*PrecipitationData, *http.Response, error) {
+	return getPrecipitationData(ctx, (*service)(s), PrecipitationParameterAmountMonthly, station, period, opts...)
+}
+
+// GetStationsWithMonthlyAmounts retrieves all stations with monthly precipitation amounts
+func (s *PrecipitationService) GetStationsWithMonthlyAmounts(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PrecipitationParameterAmountMonthly, includeInactive)
+}
@@ -0,0 +1,268 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPrecipitationService_GetDailyAmounts_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/5/station/97100/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"from": 1533175201000, "to": 1533261600000, "ref": "2018-08-02", "value": "4.2", "quality": "Y"}],
+		"updated": 1533470400000,
+		"parameter": {
+		"key": "5",
+		"name": "Nederbördsmängd",
+		"summary": "summa 1 dygn, 1 gång/dygn, kl 06",
+		"unit": "millimeter"
+		},
+		"station": {
+		"key": "97100",
+		"name": "Tullinge A",
+		"owner": "SMHI",
+		"height": 1.5
+		},
+		"period": {
+		"key": "latest-day",
+		"from": 1533380401000,
+		"to": 1533470400000,
+		"summary": "Data från senaste dygnet",
+		"sampling": "24 timmar"
+		},
+		"position": [
+		{
+		"from": 818985600000,
+		"to": 1533470400000,
+		"height": 45.0,
+		"latitude": 59.1789,
+		"longitude": 17.9125
+		}
+		]}`)
+	})
+
+	precip, _, err := client.Precipitations.GetDailyAmounts(context.Background(), 97100, PeriodLatestDay)
+	if err != nil {
+		t.Fatalf("Precipitations.GetDailyAmounts returned error: %v", err)
+	}
+
+	want := &PrecipitationData{
+		ObservationData: ObservationData{
+			Value: []ObservationValue{
+				{
+					From:    1533175201000,
+					To:      1533261600000,
+					Ref:     "2018-08-02",
+					Value:   "4.2",
+					Quality: "Y",
+				},
+			},
+			Updated: 1533470400000,
+			Parameter: ParameterData{
+				Key:     "5",
+				Name:    "Nederbördsmängd",
+				Summary: "summa 1 dygn, 1 gång/dygn, kl 06",
+				Unit:    "millimeter",
+			},
+			Station: StationData{
+				Key:    "97100",
+				Name:   "Tullinge A",
+				Owner:  "SMHI",
+				Height: 1.5,
+			},
+			Period: PeriodData{
+				Key:      "latest-day",
+				From:     1533380401000,
+				To:       1533470400000,
+				Summary:  "Data från senaste dygnet",
+				Sampling: "24 timmar",
+			},
+			Position: []PositionData{
+				{
+					From:      818985600000,
+					To:        1533470400000,
+					Height:    45.0,
+					Latitude:  59.1789,
+					Longitude: 17.9125,
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(precip, want) {
+		t.Errorf("Precipitations.GetDailyAmounts returned %+v, want %+v", precip, want)
+	}
+
+	amounts, err := precip.Amounts()
+	if err != nil {
+		t.Errorf("PrecipitationData.Amounts returned error: %v", err)
+	}
+	if want := []PrecipitationAmount{4.2}; !reflect.DeepEqual(amounts, want) {
+		t.Errorf("PrecipitationData.Amounts returned %v, want %v", amounts, want)
+	}
+
+	if _, err := precip.SnowDepths(); err == nil {
+		t.Errorf("PrecipitationData.SnowDepths expected error for an amount parameter")
+	}
+}
+
+func TestPrecipitationService_GetDailyAmounts_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/5/station/12345/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Precipitations.GetDailyAmounts(context.Background(), 12346, PeriodLatestDay)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Precipitations.GetDailyAmounts returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
+
+func TestPrecipitationData_typedValues(t *testing.T) {
+	tests := []struct {
+		parameter string
+		unit      string
+		value     string
+		check     func(d *PrecipitationData) (interface{}, error)
+		want      interface{}
+	}{
+		{
+			parameter: "8",
+			value:     "0.25",
+			check:     func(d *PrecipitationData) (interface{}, error) { return d.SnowDepths() },
+			want:      []SnowDepth{0.25},
+		},
+		{
+			parameter: "15",
+			unit:      "millimeter per second",
+			value:     "0.5",
+			check:     func(d *PrecipitationData) (interface{}, error) { return d.Intensities() },
+			want:      []PrecipitationIntensity{1800},
+		},
+		{
+			parameter: "15",
+			value:     "0.25",
+			check:     func(d *PrecipitationData) (interface{}, error) { return d.Intensities() },
+			want:      []PrecipitationIntensity{900},
+		},
+		{
+			parameter: "23",
+			value:     "61.0",
+			check:     func(d *PrecipitationData) (interface{}, error) { return d.Amounts() },
+			want:      []PrecipitationAmount{61.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.parameter, func(t *testing.T) {
+			d := &PrecipitationData{ObservationData: ObservationData{
+				Value:     []ObservationValue{{Value: tt.value, Quality: "G"}},
+				Parameter: ParameterData{Key: tt.parameter, Unit: tt.unit},
+			}}
+
+			got, err := tt.check(d)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrecipitationData_types(t *testing.T) {
+	d := &PrecipitationData{ObservationData: ObservationData{
+		Value:     []ObservationValue{{Value: "1", Quality: "G"}},
+		Parameter: ParameterData{Key: "18", Unit: "code"},
+	}}
+
+	if _, err := d.Amounts(); err == nil {
+		t.Errorf("PrecipitationData.Amounts expected error for a precipitation type parameter")
+	}
+	if _, err := d.Intensities(); err == nil {
+		t.Errorf("PrecipitationData.Intensities expected error for a precipitation type parameter")
+	}
+}
+
+func TestPrecipitationData_Intensities_unknownUnit(t *testing.T) {
+	d := &PrecipitationData{ObservationData: ObservationData{
+		Value:     []ObservationValue{{Value: "1.6", Quality: "G"}},
+		Parameter: ParameterData{Key: "15", Unit: "inch per hour"},
+	}}
+
+	if _, err := d.Intensities(); err == nil {
+		t.Errorf("PrecipitationData.Intensities expected error for an unknown unit")
+	}
+}
+
+func TestPrecipitationService_GetStationsWithDailySnowDepths_returnsOK(t *testing.T) {
+	data := `{
+		"key": "8",
+		"updated": 1533495600000,
+		"title": "Snödjup: Välj station (sedan tidsutsnitt)",
+		"summary": "momentanvärde, 1 gång/dygn, kl 06",
+		"valueType": "SAMPLING",
+		"station": [
+		{
+			"name": "Abisko",
+			"owner": "SMHI",
+			"id": 188800,
+			"height": 388.0,
+			"latitude": 68.3557,
+			"longitude": 18.8206,
+			"active": true,
+			"key": "188800",
+			"updated": 1533081599000,
+			"title": "Snödjup - Abisko",
+			"summary": "Latitud: 68.3557 Longitud: 18.8206 Höjd: 388.0"
+		}
+		]
+	}`
+
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/8.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, data)
+	})
+
+	p, _, err := client.Precipitations.GetStationsWithDailySnowDepths(context.Background(), true)
+	if err != nil {
+		t.Errorf("Precipitations.GetStationsWithDailySnowDepths returned error: %v", err)
+	}
+
+	want := &Parameter{
+		Key:       "8",
+		Updated:   1533495600000,
+		Title:     "Snödjup: Välj station (sedan tidsutsnitt)",
+		Summary:   "momentanvärde, 1 gång/dygn, kl 06",
+		ValueType: "SAMPLING",
+		Station: []Station{
+			{
+				Name:      "Abisko",
+				Owner:     "SMHI",
+				ID:        188800,
+				Height:    388.0,
+				Latitude:  68.3557,
+				Longitude: 18.8206,
+				Active:    true,
+				Key:       "188800",
+				Updated:   1533081599000,
+				Title:     "Snödjup - Abisko",
+				Summary:   "Latitud: 68.3557 Longitud: 18.8206 Höjd: 388.0",
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Precipitations.GetStationsWithDailySnowDepths returned %+v, want %+v", p, want)
+	}
+}
@@ -0,0 +1,52 @@
+package smhi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Air pressure parameter definitions
+const (
+	AirPressureParameterSeaLevelHourly = 9
+)
+
+// AirPressureService is a service for the air pressure queries
+type AirPressureService service
+
+// AirPressure is an air pressure in hectopascal
+type AirPressure float64
+
+// AirPressureData holds the returned air pressure data
+type AirPressureData struct {
+	ObservationData
+}
+
+// Pressures returns the values as air pressures
+func (d *AirPressureData) Pressures() ([]AirPressure, error) {
+	values, err := parseValues(d.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	pressures := make([]AirPressure, len(values))
+	for i, v := range values {
+		pressures[i] = AirPressure(v)
+	}
+
+	return pressures, nil
+}
+
+// GetHourlySeaLevelPressures retrieves the hourly air pressures reduced to sea level from a station
+func (s *AirPressureService) GetHourlySeaLevelPressures(ctx context.Context, station uint32, period string, opts ...DataOption) (*AirPressureData, *http.Response, error) {
+	od, resp, err := getObservationData(ctx, (*service)(s), AirPressureParameterSeaLevelHourly, station, period, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &AirPressureData{ObservationData: *od}, resp, nil
+}
+
+// GetStationsWithHourlySeaLevelPressures retrieves all stations with hourly air pressures reduced to sea level
+func (s *AirPressureService) GetStationsWithHourlySeaLevelPressures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), AirPressureParameterSeaLevelHourly, includeInactive)
+}
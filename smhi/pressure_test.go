@@ -0,0 +1,88 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAirPressureService_GetHourlySeaLevelPressures_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/9/station/97400/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [
+		{"date": 1533463200000, "value": "1013.2", "quality": "G"},
+		{"date": 1533466800000, "value": "", "quality": "G"}
+		],
+		"updated": 1533466800000,
+		"parameter": {
+		"key": "9",
+		"name": "Lufttryck reducerat havsytans nivå",
+		"summary": "vid havsytans nivå, momentanvärde, 1 gång/tim",
+		"unit": "hectopascal"
+		},
+		"station": {
+		"key": "97400",
+		"name": "Stockholm-Arlanda Flygplats",
+		"owner": "SMHI",
+		"height": 32.0
+		}}`)
+	})
+
+	pressure, _, err := client.AirPressure.GetHourlySeaLevelPressures(context.Background(), 97400, PeriodLatestHour)
+	if err != nil {
+		t.Fatalf("AirPressure.GetHourlySeaLevelPressures returned error: %v", err)
+	}
+
+	want := &AirPressureData{
+		ObservationData: ObservationData{
+			Value: []ObservationValue{
+				{Date: 1533463200000, Value: "1013.2", Quality: "G"},
+				{Date: 1533466800000, Value: "", Quality: "G"},
+			},
+			Updated: 1533466800000,
+			Parameter: ParameterData{
+				Key:     "9",
+				Name:    "Lufttryck reducerat havsytans nivå",
+				Summary: "vid havsytans nivå, momentanvärde, 1 gång/tim",
+				Unit:    "hectopascal",
+			},
+			Station: StationData{
+				Key:    "97400",
+				Name:   "Stockholm-Arlanda Flygplats",
+				Owner:  "SMHI",
+				Height: 32.0,
+			},
+		},
+	}
+	if !reflect.DeepEqual(pressure, want) {
+		t.Errorf("AirPressure.GetHourlySeaLevelPressures returned %+v, want %+v", pressure, want)
+	}
+
+	pressures, err := pressure.Pressures()
+	if err != nil {
+		t.Fatalf("AirPressureData.Pressures returned error: %v", err)
+	}
+	if len(pressures) != 2 || pressures[0] != 1013.2 || !math.IsNaN(float64(pressures[1])) {
+		t.Errorf("AirPressureData.Pressures returned %v, want [1013.2 NaN]", pressures)
+	}
+}
+
+func TestAirPressureService_GetHourlySeaLevelPressures_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/9/station/12345/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.AirPressure.GetHourlySeaLevelPressures(context.Background(), 12346, PeriodLatestHour)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("AirPressure.GetHourlySeaLevelPressures returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
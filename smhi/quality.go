@@ -0,0 +1,161 @@
+package smhi
+
+// Quality is the quality code of an observed value
+type Quality string
+
+// Quality codes used by SMHI
+const (
+	QualityControlled   Quality = "G"
+	QualitySuspect      Quality = "Y"
+	QualityUncontrolled Quality = "R"
+)
+
+// Description returns a description of the quality code
+func (q Quality) Description() string {
+	switch q {
+	case QualityControlled:
+		return "Controlled and approved values"
+	case QualitySuspect:
+		return "Suspect or aggregated values"
+	case QualityUncontrolled:
+		return "Uncontrolled values"
+	}
+	return "Unknown quality"
+}
+
+// worseQuality returns the worse of two quality codes, suspect values being
+// worse than uncontrolled ones
+func worseQuality(a, b Quality) Quality {
+	switch {
+	case a == QualitySuspect || b == QualitySuspect:
+		return QualitySuspect
+	case a != QualityControlled:
+		return a
+	}
+	return b
+}
+
+// QualityStats holds the number of values per quality code
+type QualityStats map[Quality]int
+
+// Total returns the total number of values
+func (s QualityStats) Total() int {
+	total := 0
+	for _, n := range s {
+		total += n
+	}
+	return total
+}
+
+// Fraction returns the fraction of the values with the quality code
+func (s QualityStats) Fraction(q Quality) float64 {
+	total := s.Total()
+	if total == 0 {
+		return 0
+	}
+	return float64(s[q]) / float64(total)
+}
+
+// add counts the values in the stats
+func (s QualityStats) add(values ...ObservationValue) {
+	for _, v := range values {
+		s[v.Quality]++
+	}
+}
+
+// DataOption configures how the values of fetched data are handled
+type DataOption func(*dataOptions)
+
+type dataOptions struct {
+	keep  []func(Quality) bool // Values are kept if all of them keep the quality
+	stats QualityStats
+}
+
+func newDataOptions(opts []DataOption) *dataOptions {
+	o := &dataOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// include reports whether the value is kept, counting it in the stats
+func (o *dataOptions) include(v ObservationValue) bool {
+	if o.stats != nil {
+		o.stats.add(v)
+	}
+	for _, keep := range o.keep {
+		if !keep(v.Quality) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply filters the values of the data
+func (o *dataOptions) apply(d *ObservationData) {
+	d.Value = o.filter(d.Value)
+}
+
+// filter returns the values that are kept
+func (o *dataOptions) filter(values []ObservationValue) []ObservationValue {
+	if o.keep == nil && o.stats == nil {
+		return values
+	}
+
+	kept := make([]ObservationValue, 0, len(values))
+	for _, v := range values {
+		if o.include(v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+// OnlyControlled keeps only the controlled values
+func OnlyControlled() DataOption {
+	return func(o *dataOptions) {
+		o.keep = append(o.keep, func(q Quality) bool {
+			return q == QualityControlled
+		})
+	}
+}
+
+// DropSuspect drops the suspect values
+func DropSuspect() DataOption {
+	return func(o *dataOptions) {
+		o.keep = append(o.keep, func(q Quality) bool {
+			return q != QualitySuspect
+		})
+	}
+}
+
+// WithQualityStats counts the values per quality code, before any filtering, into stats
+func WithQualityStats(stats QualityStats) DataOption {
+	return func(o *dataOptions) {
+		o.stats = stats
+	}
+}
+
+// FilterQuality returns a copy of the data keeping only the values with one of the quality codes
+func (d *ObservationData) FilterQuality(keep ...Quality) *ObservationData {
+	filtered := *d
+	filtered.Value = make([]ObservationValue, 0, len(d.Value))
+	for _, v := range d.Value {
+		for _, q := range keep {
+			if v.Quality == q {
+				filtered.Value = append(filtered.Value, v)
+				break
+			}
+		}
+	}
+
+	return &filtered
+}
+
+// QualityStats returns the number of values per quality code
+func (d *ObservationData) QualityStats() QualityStats {
+	stats := QualityStats{}
+	stats.add(d.Value...)
+	return stats
+}
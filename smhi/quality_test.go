@@ -0,0 +1,135 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const qualityData = `{"value": [
+	{"date": 1533459600000, "value": "20.1", "quality": "G"},
+	{"date": 1533463200000, "value": "20.4", "quality": "Y"},
+	{"date": 1533466800000, "value": "20.9", "quality": "R"}
+	],
+	"parameter": {"key": "1"},
+	"station": {"key": "97100"}}`
+
+func TestQuality_Description(t *testing.T) {
+	if got, want := QualityControlled.Description(), "Controlled and approved values"; got != want {
+		t.Errorf("QualityControlled.Description() is %q, want %q", got, want)
+	}
+	if got, want := Quality("X").Description(), "Unknown quality"; got != want {
+		t.Errorf("Quality(X).Description() is %q, want %q", got, want)
+	}
+}
+
+func TestObservationService_GetData_qualityOptions(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/1/station/97100/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, qualityData)
+	})
+
+	tests := []struct {
+		name string
+		opts []DataOption
+		want []Quality
+	}{
+		{"no options", nil, []Quality{QualityControlled, QualitySuspect, QualityUncontrolled}},
+		{"only controlled", []DataOption{OnlyControlled()}, []Quality{QualityControlled}},
+		{"drop suspect", []DataOption{DropSuspect()}, []Quality{QualityControlled, QualityUncontrolled}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, err := client.Observations.GetData(context.Background(), 1, 97100, PeriodLatestDay, tt.opts...)
+			if err != nil {
+				t.Fatalf("Observations.GetData returned error: %v", err)
+			}
+
+			var got []Quality
+			for _, v := range data.Value {
+				got = append(got, v.Quality)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Observations.GetData returned qualities %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("quality stats", func(t *testing.T) {
+		stats := QualityStats{}
+		data, _, err := client.Observations.GetData(context.Background(), 1, 97100, PeriodLatestDay, OnlyControlled(), WithQualityStats(stats))
+		if err != nil {
+			t.Fatalf("Observations.GetData returned error: %v", err)
+		}
+
+		want := QualityStats{QualityControlled: 1, QualitySuspect: 1, QualityUncontrolled: 1}
+		if !reflect.DeepEqual(stats, want) {
+			t.Errorf("WithQualityStats collected %v, want %v", stats, want)
+		}
+		if got := len(data.Value); got != 1 {
+			t.Errorf("Observations.GetData returned %d values, want 1", got)
+		}
+	})
+}
+
+func TestDataOptions_compose(t *testing.T) {
+	values := []ObservationValue{
+		{Value: "1.0", Quality: QualityControlled},
+		{Value: "2.0", Quality: QualitySuspect},
+		{Value: "3.0", Quality: QualityUncontrolled},
+	}
+
+	for _, opts := range [][]DataOption{
+		{OnlyControlled(), DropSuspect()},
+		{DropSuspect(), OnlyControlled()},
+	} {
+		kept := newDataOptions(opts).filter(values)
+		if len(kept) != 1 || kept[0].Quality != QualityControlled {
+			t.Errorf("Quality options kept %+v, want only the controlled value", kept)
+		}
+	}
+}
+
+func TestObservationData_QualityStats(t *testing.T) {
+	data := &ObservationData{Value: []ObservationValue{
+		{Value: "1.0", Quality: QualityControlled},
+		{Value: "2.0", Quality: QualityControlled},
+		{Value: "3.0", Quality: QualitySuspect},
+		{Value: "4.0", Quality: QualityUncontrolled},
+	}}
+
+	stats := data.QualityStats()
+	if got := stats.Total(); got != 4 {
+		t.Errorf("QualityStats.Total() is %d, want 4", got)
+	}
+	if got := stats.Fraction(QualityControlled); got != 0.5 {
+		t.Errorf("QualityStats.Fraction(G) is %v, want 0.5", got)
+	}
+
+	filtered := data.FilterQuality(QualityControlled, QualitySuspect)
+	if got := len(filtered.Value); got != 3 {
+		t.Errorf("ObservationData.FilterQuality returned %d values, want 3", got)
+	}
+	if got := len(data.Value); got != 4 {
+		t.Errorf("ObservationData.FilterQuality modified the original data, %d values left", got)
+	}
+}
+
+func TestNewArchiveReader_qualityOptions(t *testing.T) {
+	ar, err := NewArchiveReader(strings.NewReader(hourlyArchive), OnlyControlled())
+	if err != nil {
+		t.Fatalf("NewArchiveReader returned error: %v", err)
+	}
+
+	values := readArchive(t, ar)
+	if len(values) != 1 || values[0].Quality != QualityControlled {
+		t.Errorf("ArchiveReader values are %+v, want only controlled values", values)
+	}
+}
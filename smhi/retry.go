@@ -0,0 +1,148 @@
+package smhi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried
+// Only idempotent requests are retried, after transient transport errors or
+// responses with a retryable status code
+type RetryPolicy struct {
+	MaxAttempts int           // Total number of attempts, including the first
+	MinBackoff  time.Duration // Backoff before the first retry
+	MaxBackoff  time.Duration // Upper bound of the backoff, doubled for every retry
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for the SMHI open data endpoints
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  10 * time.Second,
+	}
+}
+
+// backoff returns the time to wait before the next attempt
+// A Retry-After header in the response is honoured, otherwise the backoff is
+// exponential with jitter
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	backoff := p.MinBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Wait at least half of the backoff, and a random part of the rest
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// transient reports whether a transport error may not recur when retried, such
+// as timeouts and dropped connections
+func transient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// drain discards and closes the response body so the connection can be reused
+func drain(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+	_ = resp.Body.Close()
+}
+
+// retry executes the request with the retry policy of the client
+func (c *Client) retry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	for attempt := 1; ; attempt++ {
+		resp, err := c.attempt(ctx, req)
+		if policy == nil || attempt >= policy.MaxAttempts || !idempotent(req.Method) || ctx.Err() != nil {
+			return resp, err
+		}
+		if err != nil && !transient(err) {
+			return nil, err
+		}
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, err
+		}
+		if resp != nil {
+			drain(resp)
+		}
+		c.logf("smhi: retrying %s %s in %v after attempt %d", req.Method, req.URL, wait, attempt)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package smhi
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestDo_retriesIntermittentFailures(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		case 2:
+			// Drop the connection without a response
+			hj, _ := w.(http.Hijacker)
+			conn, _, _ := hj.Hijack()
+			conn.Close()
+		default:
+			fmt.Fprint(w, `{"Name":"test"}`)
+		}
+	})
+
+	req, _ := client.NewRequest("GET", ".")
+	body := new(struct{ Name string })
+	if _, err := client.Do(context.Background(), req, body); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if body.Name != "test" {
+		t.Errorf("Response body name is %q, want %q", body.Name, "test")
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Server was called %d times, want 3", got)
+	}
+}
+
+// errTransport fails every round trip with its error, counting the calls
+type errTransport struct {
+	err   error
+	calls int32
+}
+
+func (t *errTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	atomic.AddInt32(&t.calls, 1)
+	return nil, t.err
+}
+
+// timeoutError is a network error that timed out
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestDo_retriesTransientErrorsOnly(t *testing.T) {
+	tests := []struct {
+		err   error
+		calls int32
+	}{
+		{timeoutError{}, 3},
+		{x509.UnknownAuthorityError{}, 1},
+		{errors.New("unsupported protocol scheme"), 1},
+	}
+	for _, tt := range tests {
+		transport := &errTransport{err: tt.err}
+		client, err := NewClient(WithHTTPClient(&http.Client{Transport: transport}), WithRetryPolicy(testRetryPolicy()))
+		if err != nil {
+			t.Fatalf("NewClient returned error: %v", err)
+		}
+
+		req, _ := client.NewRequest("GET", ".")
+		if _, err := client.Do(context.Background(), req, nil); err == nil {
+			t.Errorf("Do expected error for %v", tt.err)
+		}
+		if got := atomic.LoadInt32(&transport.calls); got != tt.calls {
+			t.Errorf("Transport was called %d times for %v, want %d", got, tt.err, tt.calls)
+		}
+	}
+}
+
+// countingLogger counts the logged messages
+type countingLogger struct{ n int32 }
+
+func (l *countingLogger) Printf(string, ...interface{}) { atomic.AddInt32(&l.n, 1) }
+
+func TestDo_noRetryForRateLimitDeadline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+	client.RateLimiter = NewRateLimiter(1, 1)
+	logger := &countingLogger{}
+	client.Logger = logger
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, _ := client.NewRequest("GET", ".")
+	if _, err := client.Do(ctx, req, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Do returned %v, want context.DeadlineExceeded", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Server was called %d times, want 1", got)
+	}
+	// Only the unavailable response is retried, not the rate limit error
+	if got := atomic.LoadInt32(&logger.n); got != 1 {
+		t.Errorf("Do retried %d times, want 1", got)
+	}
+}
+
+func TestDo_retriesExhausted(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "Bad Gateway", http.StatusBadGateway)
+	})
+
+	req, _ := client.NewRequest("GET", ".")
+	_, err := client.Do(context.Background(), req, nil)
+	if !errors.Is(err, ErrServerError) {
+		t.Errorf("Do returned %v, want ErrServerError", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("Server was called %d times, want 3", got)
+	}
+}
+
+func TestDo_noRetryForClientErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.NotFound(w, r)
+	})
+
+	req, _ := client.NewRequest("GET", ".")
+	if _, err := client.Do(context.Background(), req, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Do returned %v, want ErrNotFound", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Server was called %d times, want 1", got)
+	}
+}
+
+func TestDo_noRetryForNonIdempotentRequests(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+	})
+
+	req, _ := client.NewRequest("POST", ".")
+	client.Do(context.Background(), req, nil)
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Server was called %d times, want 1", got)
+	}
+}
+
+func TestDo_retryRespectsDeadline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.RetryPolicy = testRetryPolicy()
+
+	var calls int32
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	req, _ := client.NewRequest("GET", ".")
+	_, err := client.Do(ctx, req, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Do returned %v, want ErrRateLimited", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Do waited %v for a retry past the deadline", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("Server was called %d times, want 1", got)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 150 * time.Millisecond, 300 * time.Millisecond},
+		{4, 150 * time.Millisecond, 300 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := p.backoff(tt.attempt, nil); got < tt.min || got > tt.max {
+				t.Errorf("backoff(%d) is %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+			}
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if got := p.backoff(1, resp); got != 2*time.Second {
+		t.Errorf("backoff with Retry-After is %v, want 2s", got)
+	}
+}
@@ -3,51 +3,182 @@ package smhi
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 )
 
 const (
-	baseURL = "https://opendata-download-metobs.smhi.se/"
+	baseURL         = "https://opendata-download-metobs.smhi.se/"
+	forecastBaseURL = "https://opendata-download-metfcst.smhi.se/"
+	oceanBaseURL    = "https://opendata-download-ocobs.smhi.se/"
+	hydroBaseURL    = "https://opendata-download-hydroobs.smhi.se/"
+	userAgent       = "smhi-api-client"
+
+	defaultAPIVersion = "latest"
+
+	defaultCacheEntries = 256
+	defaultCacheBytes   = 32 << 20
 )
 
 // Client is a client
 type Client struct {
 	client  *http.Client
+	timeout time.Duration
+	// BaseURL is the base URL of the observation services
 	BaseURL *url.URL
+	// forecastURL is the base URL of the forecast service
+	forecastURL *url.URL
+	// oceanURL is the base URL of the ocean service
+	oceanURL *url.URL
+	// hydroURL is the base URL of the hydrological service
+	hydroURL *url.URL
+
+	// UserAgent is sent with every request
+	UserAgent string
+
+	// RetryPolicy configures retries of failed requests, nil disables retries
+	RetryPolicy *RetryPolicy
+	// RateLimiter limits the rate of requests, nil disables rate limiting
+	RateLimiter *RateLimiter
+	// InFlightLimiter caps the number of concurrent requests, nil disables the cap
+	InFlightLimiter *InFlightLimiter
+	// Cache stores responses to GET requests, an LRUCache by default, nil disables caching
+	Cache Cache
+	// Offline serves requests only from the cache, failing with ErrCacheMiss otherwise
+	Offline bool
+	// Logger logs the activity of the client, nil disables logging
+	Logger Logger
 
-	common service
+	throttle   throttleStats
+	apiVersion apiVersion
+	// Each observation API has its own versions
+	oceanVersion apiVersion
+	hydroVersion apiVersion
+	grid         gridCache
 
-	Temperatures *TemperatureService
+	common   service
+	forecast service
+	ocean    service
+	hydro    service
+
+	API            *APIService
+	Observations   *ObservationService
+	Temperatures   *TemperatureService
+	Precipitations *PrecipitationService
+	Humidity       *HumidityService
+	Sunshine       *SunshineService
+	Wind           *WindService
+	AirPressure    *AirPressureService
+	Visibility     *VisibilityService
+	Clouds         *CloudService
+	PresentWeather *PresentWeatherService
+	Forecasts      *ForecastService
+	Ocean          *OceanService
+	Hydro          *HydroService
 }
 
+// service is shared by the services of an API, requests are resolved against
+// baseURL or the BaseURL of the client if nil, and resources are found by the
+// links of the API version of version
 type service struct {
-	client *Client
+	client  *Client
+	baseURL *url.URL
+	version *apiVersion
+	links   *linkCache
+}
+
+// newRequest creates a new request for a resource of the service
+func (s *service) newRequest(method, urlStr string) (*http.Request, error) {
+	if s.baseURL == nil {
+		return s.client.NewRequest(method, urlStr)
+	}
+	return s.client.newRequest(s.baseURL, method, urlStr)
 }
 
 // Service is the main service
 type Service service
 
-// NewClient creates a new client
-func NewClient(httpClient *http.Client) *Client {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
+// NewClient creates a new client configured by the options
+func NewClient(opts ...Option) (*Client, error) {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	forecastURL, err := url.Parse(forecastBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	oceanURL, err := url.Parse(oceanBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	hydroURL, err := url.Parse(hydroBaseURL)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		client:      http.DefaultClient,
+		BaseURL:     parsedURL,
+		forecastURL: forecastURL,
+		oceanURL:    oceanURL,
+		hydroURL:    hydroURL,
+		UserAgent:   userAgent,
+		Cache:       NewLRUCache(defaultCacheEntries, defaultCacheBytes),
 	}
-	parsedURL, _ := url.Parse(baseURL)
-	c := &Client{client: httpClient, BaseURL: parsedURL}
 
-	c.common.client = c
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.timeout > 0 {
+		// Copy the HTTP client so that a shared client is left untouched
+		httpClient := *c.client
+		httpClient.Timeout = c.timeout
+		c.client = &httpClient
+	}
 
+	c.common = service{client: c, version: &c.apiVersion, links: newLinkCache()}
+
+	c.API = (*APIService)(&c.common)
+	c.Observations = (*ObservationService)(&c.common)
 	c.Temperatures = (*TemperatureService)(&c.common)
+	c.Precipitations = (*PrecipitationService)(&c.common)
+	c.Humidity = (*HumidityService)(&c.common)
+	c.Sunshine = (*SunshineService)(&c.common)
+	c.Wind = (*WindService)(&c.common)
+	c.AirPressure = (*AirPressureService)(&c.common)
+	c.Visibility = (*VisibilityService)(&c.common)
+	c.Clouds = (*CloudService)(&c.common)
+	c.PresentWeather = (*PresentWeatherService)(&c.common)
+
+	c.forecast = service{client: c, baseURL: c.forecastURL}
+	c.Forecasts = (*ForecastService)(&c.forecast)
+
+	c.ocean = service{client: c, baseURL: c.oceanURL, version: &c.oceanVersion, links: newLinkCache()}
+	c.Ocean = (*OceanService)(&c.ocean)
+
+	c.hydro = service{client: c, baseURL: c.hydroURL, version: &c.hydroVersion, links: newLinkCache()}
+	c.Hydro = (*HydroService)(&c.hydro)
 
-	return c
+	return c, nil
 }
 
 // NewRequest creates a new request for a resource
 func (c *Client) NewRequest(method, urlStr string) (*http.Request, error) {
-	u, err := c.BaseURL.Parse(urlStr)
+	return c.newRequest(c.BaseURL, method, urlStr)
+}
+
+// newRequest creates a new request for a resource relative to base
+func (c *Client) newRequest(base *url.URL, method, urlStr string) (*http.Request, error) {
+	u, err := base.Parse(urlStr)
 	if err != nil {
 		return nil, err
 	}
@@ -58,15 +189,39 @@ func (c *Client) NewRequest(method, urlStr string) (*http.Request, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 
 	return req, nil
 }
 
-// Do executes the request
-func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (resp *http.Response, err error) {
-	req = req.WithContext(ctx)
+// send executes the request, serving it from the cache when possible and
+// retrying it according to the retry policy, and leaves the response body to the caller
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.sendCached(ctx, req.WithContext(ctx))
+}
+
+// version returns the API version used for requests
+func (c *Client) version() string {
+	return c.apiVersion.get()
+}
+
+// APIVersion returns the version of the meteorological observation API used for requests
+func (c *Client) APIVersion() string {
+	return c.version()
+}
+
+// logf logs a message if the client has a logger
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.Logger != nil {
+		c.Logger.Printf(format, v...)
+	}
+}
 
-	resp, err = c.client.Do(req)
+// attempt executes a single attempt of the request
+func (c *Client) attempt(ctx context.Context, req *http.Request) (*http.Response, error) {
+	resp, err := c.doThrottled(ctx, req)
 	if err != nil {
 		select {
 		case <-ctx.Done():
@@ -77,8 +232,20 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (resp
 		return nil, err
 	}
 
+	return resp, nil
+}
+
+// Do executes the request
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (resp *http.Response, err error) {
+	resp, err = c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	defer func() {
-		err = resp.Body.Close()
+		if closeErr := resp.Body.Close(); err == nil {
+			err = closeErr
+		}
 	}()
 
 	err = CheckResponse(resp)
@@ -106,22 +273,67 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (resp
 	return resp, err
 }
 
+// maxErrorBodySize limits how much of an error response body is kept
+const maxErrorBodySize = 64 << 10
+
+// Errors matched by error responses, usable with errors.Is
+var (
+	ErrNotFound    = errors.New("smhi: resource not found")
+	ErrRateLimited = errors.New("smhi: rate limited")
+	ErrServerError = errors.New("smhi: server error")
+)
+
 // CheckResponse checks the response for error codes in the response
+// The body of an error response is read and kept in the returned ErrorResponse
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
 
-	return &ErrorResponse{Response: r}
+	errorResponse := &ErrorResponse{Response: r}
+	if r.Request != nil && r.Request.URL != nil {
+		errorResponse.URL = r.Request.URL.String()
+	}
+	if r.Body != nil {
+		errorResponse.Body, _ = ioutil.ReadAll(io.LimitReader(r.Body, maxErrorBodySize))
+	}
 
+	return errorResponse
 }
 
 // ErrorResponse wraps a response as an error
 type ErrorResponse struct {
 	Response *http.Response
+	URL      string // URL of the request
+	Body     []byte // Body of the response, as returned by SMHI
 }
 
 // Error implements the errorer interface
 func (e *ErrorResponse) Error() string {
-	return fmt.Sprintf("Error code %d", e.Response.StatusCode)
+	msg := fmt.Sprintf("Error code %d", e.Response.StatusCode)
+	if e.URL != "" {
+		msg = fmt.Sprintf("%s: %s", e.URL, msg)
+	}
+	if body := strings.TrimSpace(string(e.Body)); body != "" {
+		msg = fmt.Sprintf("%s: %s", msg, body)
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *ErrorResponse) Is(target error) bool {
+	switch c := e.Response.StatusCode; target {
+	case ErrNotFound:
+		return c == http.StatusNotFound
+	case ErrRateLimited:
+		return c == http.StatusTooManyRequests
+	case ErrServerError:
+		return c >= 500 && c <= 599
+	}
+	return false
+}
+
+// Retryable reports whether the request may succeed if retried
+func (e *ErrorResponse) Retryable() bool {
+	return retryableStatus(e.Response.StatusCode)
 }
@@ -2,27 +2,164 @@ package smhi
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
-	"net/url"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 var baseURLPath = "/api-test"
 
-func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown func()) {
-	mux = http.NewServeMux()
+// testMux records the patterns of its handlers, and serves the documents linking
+// the root of an API to them for requests no handler matches
+type testMux struct {
+	*http.ServeMux
+	serverURL string
+
+	mu       sync.Mutex
+	patterns []string
+}
+
+// HandleFunc registers the handler for the pattern
+func (m *testMux) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	m.mu.Lock()
+	m.patterns = append(m.patterns, pattern)
+	m.mu.Unlock()
+
+	m.ServeMux.HandleFunc(pattern, handler)
+}
+
+func (m *testMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := m.ServeMux.Handler(r); pattern != "" {
+		m.ServeMux.ServeHTTP(w, r)
+		return
+	}
+	m.serveLinks(w, r)
+}
+
+// serveLinks serves the root, version, parameter, station and period documents
+// of an API, listing links to the resources below them that have handlers
+func (m *testMux) serveLinks(w http.ResponseWriter, r *http.Request) {
+	var prefix, doc string
+	if i := strings.Index(r.URL.Path, "/api.json"); i >= 0 && r.URL.Path[i:] == "/api.json" {
+		prefix = r.URL.Path[:i]
+	} else if i := strings.Index(r.URL.Path, "/api/version/"); i >= 0 && strings.HasSuffix(r.URL.Path, ".json") {
+		prefix = r.URL.Path[:i]
+		doc = strings.TrimSuffix(r.URL.Path[i+len("/api/version/"):], ".json")
+	} else {
+		http.NotFound(w, r)
+		return
+	}
+	href := func(path []string, ext string) string {
+		return m.serverURL + baseURLPath + prefix + "/api/version/" + strings.Join(path, "/") + ext
+	}
+
+	// The resource paths of the handlers of the API, with the extension of the data
+	type resource struct {
+		path []string
+		ext  string
+	}
+	var resources []resource
+	m.mu.Lock()
+	for _, pattern := range m.patterns {
+		if !strings.HasPrefix(pattern, prefix+"/api/version/") {
+			continue
+		}
+		path := strings.TrimPrefix(pattern, prefix+"/api/version/")
+		ext := ""
+		if i := strings.LastIndex(path, "."); i > strings.LastIndex(path, "/") {
+			path, ext = path[:i], path[i:]
+		}
+		resources = append(resources, resource{strings.Split(path, "/"), ext})
+	}
+	m.mu.Unlock()
+
+	if doc == "" {
+		versions := map[string]bool{defaultAPIVersion: true}
+		for _, res := range resources {
+			versions[res.path[0]] = true
+		}
+		api := &API{Key: "api"}
+		for v := range versions {
+			updated := Timestamp(1)
+			if v == defaultAPIVersion {
+				updated = 2
+			}
+			api.Version = append(api.Version, Version{Key: v, Updated: updated,
+				Link: []Link{{Rel: "version", Type: FormatJSON, Href: href([]string{v}, ".json")}}})
+		}
+		json.NewEncoder(w).Encode(api)
+		return
+	}
+
+	// Lists the resources directly below the document, keyed by their list
+	path := strings.Split(doc, "/")
+	found := len(path) == 1
+	for _, res := range resources {
+		if len(res.path) > len(path) && strings.Join(res.path[:len(path)], "/") == doc {
+			found = true
+		}
+	}
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	lists := map[string]string{"parameter": "resource", "station-set": "stationSet", "station": "station", "period": "period"}
+	listed := map[string]map[string]bool{}
+	body := map[string][]linkItem{}
+	for _, res := range resources {
+		if len(res.path) <= len(path) || strings.Join(res.path[:len(path)], "/") != doc {
+			continue
+		}
+		name := res.path[len(path)]
+		if name == "data" && len(res.path) == len(path)+1 {
+			typ := map[string]string{".json": FormatJSON, ".csv": FormatCSV, ".xml": FormatXML}[res.ext]
+			body["data"] = append(body["data"], linkItem{Key: "data",
+				Link: []Link{{Rel: "data", Type: typ, Href: href(res.path, res.ext)}}})
+			continue
+		}
+		list, ok := lists[name]
+		if !ok || len(res.path) < len(path)+2 {
+			continue
+		}
+		key := res.path[len(path)+1]
+		if listed[list] == nil {
+			listed[list] = map[string]bool{}
+		}
+		if listed[list][key] {
+			continue
+		}
+		listed[list][key] = true
+		body[list] = append(body[list], linkItem{Key: key,
+			Link: []Link{{Rel: name, Type: FormatJSON, Href: href(res.path[:len(path)+2], ".json")}}})
+	}
+	json.NewEncoder(w).Encode(body)
+}
+
+func setup() (client *Client, mux *testMux, serverURL string, teardown func()) {
+	mux = &testMux{ServeMux: http.NewServeMux()}
 
 	apiHandler := http.NewServeMux()
 	apiHandler.Handle(baseURLPath+"/", http.StripPrefix(baseURLPath, mux))
 
 	server := httptest.NewServer(apiHandler)
+	mux.serverURL = server.URL
 
-	client = NewClient(nil)
-	url, _ := url.Parse(server.URL + baseURLPath + "/")
-	client.BaseURL = url
+	client, err := NewClient(
+		WithBaseURL(server.URL+baseURLPath+"/"),
+		WithForecastBaseURL(server.URL+baseURLPath+"/"),
+		WithOceanBaseURL(server.URL+baseURLPath+"/ocobs/"),
+		WithHydroBaseURL(server.URL+baseURLPath+"/hydroobs/"),
+	)
+	if err != nil {
+		panic(err)
+	}
 
 	teardown = server.Close
 
@@ -36,15 +173,80 @@ func testMethod(t *testing.T, r *http.Request, want string) {
 }
 
 func TestNewClient(t *testing.T) {
-	c := NewClient(nil)
+	c, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
 
 	if got, want := c.BaseURL.String(), baseURL; got != want {
 		t.Errorf("NewClient BaseURL is %v, want %v", got, want)
 	}
+	if got, want := c.version(), "latest"; got != want {
+		t.Errorf("NewClient API version is %v, want %v", got, want)
+	}
+}
+
+func TestNewClient_options(t *testing.T) {
+	httpClient := &http.Client{}
+	c, err := NewClient(
+		WithHTTPClient(httpClient),
+		WithBaseURL("https://example.com/smhi"),
+		WithUserAgent("test-agent"),
+		WithTimeout(5*time.Second),
+		WithAPIVersion("1.0"),
+		WithCache(nil),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	if got, want := c.BaseURL.String(), "https://example.com/smhi/"; got != want {
+		t.Errorf("NewClient BaseURL is %v, want %v", got, want)
+	}
+	if got, want := c.client.Timeout, 5*time.Second; got != want {
+		t.Errorf("NewClient timeout is %v, want %v", got, want)
+	}
+	if httpClient.Timeout != 0 {
+		t.Errorf("NewClient modified the given HTTP client")
+	}
+	if c.Cache != nil {
+		t.Errorf("NewClient cache is %v, want nil", c.Cache)
+	}
+
+	req, _ := c.NewRequest("GET", "test")
+	if got, want := req.Header.Get("User-Agent"), "test-agent"; got != want {
+		t.Errorf("NewRequest User-Agent is %v, want %v", got, want)
+	}
+	if got, want := c.version(), "1.0"; got != want {
+		t.Errorf("NewClient API version is %v, want %v", got, want)
+	}
+}
+
+func TestNewClient_invalidOptions(t *testing.T) {
+	tests := []Option{
+		WithHTTPClient(nil),
+		WithBaseURL("://example.com"),
+		WithBaseURL("example.com"),
+		WithUserAgent(""),
+		WithTimeout(0),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 0}),
+		WithRetryPolicy(&RetryPolicy{MaxAttempts: 2, MinBackoff: time.Second}),
+		WithRateLimit(0, 1),
+		WithRateLimit(1, 0),
+		WithMaxInFlight(0),
+		WithLogger(nil),
+		WithAPIVersion("1.0/x"),
+	}
+
+	for i, opt := range tests {
+		if _, err := NewClient(opt); err == nil {
+			t.Errorf("NewClient with option %d returned no error", i)
+		}
+	}
 }
 
 func TestNewRequest(t *testing.T) {
-	c := NewClient(nil)
+	c, _ := NewClient()
 
 	inURL, outURL := "/test", baseURL+"test"
 	req, _ := c.NewRequest("GET", inURL)
@@ -88,13 +290,61 @@ func TestDo_httpError(t *testing.T) {
 	})
 
 	req, _ := client.NewRequest("GET", ".")
-	resp, _ := client.Do(context.Background(), req, nil)
+	resp, err := client.Do(context.Background(), req, nil)
 
-	// TODO: Needs checking err flow
-	//if err == nil {
-	//	t.Fatalf("Expected error, got %v instead.", resp)
-	//}
+	if err == nil {
+		t.Fatalf("Expected error, got %v instead.", resp)
+	}
 	if resp.StatusCode != 400 {
 		t.Errorf("Expected HTTP 400 error, got %d status code.", resp.StatusCode)
 	}
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("Expected *ErrorResponse, got %T", err)
+	}
+	if got, want := string(errorResponse.Body), "Bad Request\n"; got != want {
+		t.Errorf("ErrorResponse.Body is %q, want %q", got, want)
+	}
+	if got, want := errorResponse.URL, req.URL.String(); got != want {
+		t.Errorf("ErrorResponse.URL is %q, want %q", got, want)
+	}
+	if errorResponse.Retryable() {
+		t.Errorf("ErrorResponse.Retryable() is true for a bad request")
+	}
+}
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		code      int
+		target    error
+		is        bool
+		retryable bool
+	}{
+		{http.StatusNotFound, ErrNotFound, true, false},
+		{http.StatusNotFound, ErrServerError, false, false},
+		{http.StatusTooManyRequests, ErrRateLimited, true, true},
+		{http.StatusServiceUnavailable, ErrServerError, true, true},
+		{http.StatusNotImplemented, ErrServerError, true, false},
+	}
+
+	for _, tt := range tests {
+		err := error(&ErrorResponse{Response: &http.Response{StatusCode: tt.code}})
+		if got := errors.Is(err, tt.target); got != tt.is {
+			t.Errorf("errors.Is(%d, %v) is %v, want %v", tt.code, tt.target, got, tt.is)
+		}
+		if got := err.(*ErrorResponse).Retryable(); got != tt.retryable {
+			t.Errorf("Retryable() for %d is %v, want %v", tt.code, got, tt.retryable)
+		}
+	}
+}
+
+func TestObservationService_GetData_notFound(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	_, _, err := client.Observations.GetData(context.Background(), 1, 12346, PeriodLatestDay)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Observations.GetData returned %v, want ErrNotFound", err)
+	}
 }
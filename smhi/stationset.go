@@ -0,0 +1,86 @@
+package smhi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// StationSetAll is the station set of all stations of a parameter
+const StationSetAll = "all"
+
+// StationSetData holds the values of all stations of a station set
+type StationSetData struct {
+	Updated   Timestamp       `json:"updated,omitempty"`
+	Parameter ParameterData   `json:"parameter,omitempty"`
+	Period    PeriodData      `json:"period,omitempty"`
+	Link      []Link          `json:"link,omitempty"`
+	Station   []StationValues `json:"station,omitempty"`
+}
+
+// StationValues holds the values of a station in a station set
+type StationValues struct {
+	Key           string             `json:"key,omitempty"`
+	Name          string             `json:"name,omitempty"`
+	Owner         string             `json:"owner,omitempty"`
+	OwnerCategory string             `json:"ownerCategory,omitempty"`
+	From          Timestamp          `json:"from,omitempty"`
+	To            Timestamp          `json:"to,omitempty"`
+	Height        float32            `json:"height,omitempty"`
+	Latitude      float32            `json:"latitude,omitempty"`
+	Longitude     float32            `json:"longitude,omitempty"`
+	Value         []ObservationValue `json:"value,omitempty"`
+}
+
+// Latest returns the most recent value of the station
+func (s StationValues) Latest() (ObservationValue, bool) {
+	var latest ObservationValue
+	ok := false
+	for _, v := range s.Value {
+		if !ok || v.timestamp() > latest.timestamp() {
+			latest, ok = v, true
+		}
+	}
+	return latest, ok
+}
+
+// GetStationSetData retrieves the values of all stations of a parameter in one
+// request, for the periods PeriodLatestHour or PeriodLatestDay
+func (s *ObservationService) GetStationSetData(ctx context.Context, parameter int, period string, opts ...DataOption) (*StationSetData, *http.Response, error) {
+	link, resp, err := (*service)(s).resolve(ctx, "parameter", strconv.Itoa(parameter), "station-set", StationSetAll, "period", period, "data", FormatJSON)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sd := &StationSetData{}
+	resp, err = (*service)(s).follow(ctx, link, sd)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	o := newDataOptions(opts)
+	for i := range sd.Station {
+		sd.Station[i].Value = o.filter(sd.Station[i].Value)
+	}
+
+	return sd, resp, nil
+}
+
+// GetLatestValues retrieves the latest value of every station of a parameter
+// from the latest hour, keyed by station key
+// Stations without a value are left out
+func (s *ObservationService) GetLatestValues(ctx context.Context, parameter int, opts ...DataOption) (map[string]ObservationValue, *http.Response, error) {
+	sd, resp, err := s.GetStationSetData(ctx, parameter, PeriodLatestHour, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	latest := make(map[string]ObservationValue, len(sd.Station))
+	for _, st := range sd.Station {
+		if v, ok := st.Latest(); ok {
+			latest[st.Key] = v
+		}
+	}
+
+	return latest, resp, nil
+}
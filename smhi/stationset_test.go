@@ -0,0 +1,54 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestObservationService_GetStationSetData(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/1/station-set/all/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"updated": 1533495600000,
+			"parameter": {"key": "1", "name": "Lufttemperatur", "summary": "momentanvärde, 1 gång/tim", "unit": "degree celsius"},
+			"period": {"key": "latest-hour", "from": 1533492000001, "to": 1533495600000, "summary": "Data från senaste timmen", "sampling": "1 timme"},
+			"station": [
+				{"key": "97100", "name": "Stockholm", "owner": "SMHI", "ownerCategory": "CLIMATE", "height": 44.0,
+					"latitude": 59.3417, "longitude": 18.0549, "value": [{"date": 1533495600000, "value": "18.2", "quality": "G"}]},
+				{"key": "97200", "name": "Uppsala", "owner": "SMHI", "ownerCategory": "CLIMATE", "height": 21.0,
+					"latitude": 59.8471, "longitude": 17.6320, "value": [{"date": 1533495600000, "value": "17.4", "quality": "Y"}]},
+				{"key": "97300", "name": "Gävle", "owner": "SMHI", "ownerCategory": "CLIMATE", "height": 16.0,
+					"latitude": 60.6166, "longitude": 17.1668, "value": null}
+			]
+		}`)
+	})
+
+	sd, _, err := client.Observations.GetStationSetData(context.Background(), 1, PeriodLatestHour, OnlyControlled())
+	if err != nil {
+		t.Fatalf("Observations.GetStationSetData returned error: %v", err)
+	}
+	if got, want := len(sd.Station), 3; got != want {
+		t.Fatalf("Observations.GetStationSetData returned %d stations, want %d", got, want)
+	}
+	if got := sd.Station[1].Value; len(got) != 0 {
+		t.Errorf("Observations.GetStationSetData kept suspect values %+v", got)
+	}
+
+	latest, _, err := client.Observations.GetLatestValues(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Observations.GetLatestValues returned error: %v", err)
+	}
+	want := map[string]ObservationValue{
+		"97100": {Date: 1533495600000, Value: "18.2", Quality: "G"},
+		"97200": {Date: 1533495600000, Value: "17.4", Quality: "Y"},
+	}
+	if !reflect.DeepEqual(latest, want) {
+		t.Errorf("Observations.GetLatestValues returned %+v, want %+v", latest, want)
+	}
+}
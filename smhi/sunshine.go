@@ -1,6 +1,60 @@
 package smhi
 
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+)
+
 // Sunshine parameter definitions
 const (
-	SunshineParameterAmountHourly = 10 // Not implemented
+	SunshineParameterAmountHourly = 10
 )
+
+// SunshineMissing is the duration returned for missing sunshine values
+const SunshineMissing time.Duration = -1
+
+// SunshineService is a service for the sunshine queries
+type SunshineService service
+
+// SunshineData holds the returned sunshine data
+type SunshineData struct {
+	ObservationData
+}
+
+// Durations returns the values as sunshine durations
+// SMHI reports the sunshine duration in seconds per hour, missing values are
+// returned as SunshineMissing
+func (d *SunshineData) Durations() ([]time.Duration, error) {
+	values, err := parseValues(d.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make([]time.Duration, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) {
+			durations[i] = SunshineMissing
+			continue
+		}
+		durations[i] = time.Duration(v * float64(time.Second))
+	}
+
+	return durations, nil
+}
+
+// GetHourlySunshine retrieves the hourly sunshine duration from a station
+func (s *SunshineService) GetHourlySunshine(ctx context.Context, station uint32, period string, opts ...DataOption) (*SunshineData, *http.Response, error) {
+	od, resp, err := getObservationData(ctx, (*service)(s), SunshineParameterAmountHourly, station, period, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &SunshineData{ObservationData: *od}, resp, nil
+}
+
+// GetStationsWithHourlySunshine retrieves all stations with hourly sunshine duration
+func (s *SunshineService) GetStationsWithHourlySunshine(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), SunshineParameterAmountHourly, includeInactive)
+}
@@ -0,0 +1,85 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSunshineService_GetHourlySunshine_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/10/station/98735/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "2700", "quality": "Y"}],
+		"updated": 1533466800000,
+		"parameter": {
+		"key": "10",
+		"name": "Solskenstid",
+		"summary": "summa 1 timme, 1 gång/tim",
+		"unit": "second"
+		},
+		"station": {
+		"key": "98735",
+		"name": "Stockholm Sol",
+		"owner": "SMHI",
+		"height": 0.0
+		}}`)
+	})
+
+	sunshine, _, err := client.Sunshine.GetHourlySunshine(context.Background(), 98735, PeriodLatestHour)
+	if err != nil {
+		t.Fatalf("Sunshine.GetHourlySunshine returned error: %v", err)
+	}
+
+	want := &SunshineData{
+		ObservationData: ObservationData{
+			Value: []ObservationValue{
+				{
+					Date:    1533466800000,
+					Value:   "2700",
+					Quality: "Y",
+				},
+			},
+			Updated: 1533466800000,
+			Parameter: ParameterData{
+				Key:     "10",
+				Name:    "Solskenstid",
+				Summary: "summa 1 timme, 1 gång/tim",
+				Unit:    "second",
+			},
+			Station: StationData{
+				Key:   "98735",
+				Name:  "Stockholm Sol",
+				Owner: "SMHI",
+			},
+		},
+	}
+	if !reflect.DeepEqual(sunshine, want) {
+		t.Errorf("Sunshine.GetHourlySunshine returned %+v, want %+v", sunshine, want)
+	}
+
+	durations, err := sunshine.Durations()
+	if err != nil {
+		t.Errorf("SunshineData.Durations returned error: %v", err)
+	}
+	if want := []time.Duration{45 * time.Minute}; !reflect.DeepEqual(durations, want) {
+		t.Errorf("SunshineData.Durations returned %v, want %v", durations, want)
+	}
+}
+
+func TestSunshineData_Durations_missing(t *testing.T) {
+	sunshine := &SunshineData{ObservationData{Value: []ObservationValue{{Value: "3600"}, {Value: ""}}}}
+
+	durations, err := sunshine.Durations()
+	if err != nil {
+		t.Fatalf("SunshineData.Durations returned error: %v", err)
+	}
+	if want := []time.Duration{time.Hour, SunshineMissing}; !reflect.DeepEqual(durations, want) {
+		t.Errorf("SunshineData.Durations returned %v, want %v", durations, want)
+	}
+}
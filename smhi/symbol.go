@@ -0,0 +1,146 @@
+package smhi
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// WeatherSymbol is a Wsymb2 weather symbol code of the forecasts, from 1 for
+// clear sky to 27 for heavy snowfall
+type WeatherSymbol int
+
+type weatherSymbolDescription struct {
+	swedish  string
+	english  string
+	icon     string
+	dayNight bool // The icon has day and night variants
+}
+
+var weatherSymbolDescriptions = map[WeatherSymbol]weatherSymbolDescription{
+	1:  {"Klar himmel", "Clear sky", "clear", true},
+	2:  {"Nästan klar himmel", "Nearly clear sky", "mostly-clear", true},
+	3:  {"Växlande molnighet", "Variable cloudiness", "partly-cloudy", true},
+	4:  {"Halvklar himmel", "Halfclear sky", "partly-cloudy", true},
+	5:  {"Molnig himmel", "Cloudy sky", "mostly-cloudy", false},
+	6:  {"Mulet", "Overcast", "overcast", false},
+	7:  {"Dimma", "Fog", "fog", false},
+	8:  {"Lätta regnskurar", "Light rain showers", "rain-showers-light", true},
+	9:  {"Måttliga regnskurar", "Moderate rain showers", "rain-showers", true},
+	10: {"Kraftiga regnskurar", "Heavy rain showers", "rain-showers-heavy", true},
+	11: {"Åskskurar", "Thunderstorm", "thunderstorm-showers", true},
+	12: {"Lätta byar av regn och snö", "Light sleet showers", "sleet-showers-light", true},
+	13: {"Måttliga byar av regn och snö", "Moderate sleet showers", "sleet-showers", true},
+	14: {"Kraftiga byar av regn och snö", "Heavy sleet showers", "sleet-showers-heavy", true},
+	15: {"Lätta snöbyar", "Light snow showers", "snow-showers-light", true},
+	16: {"Måttliga snöbyar", "Moderate snow showers", "snow-showers", true},
+	17: {"Kraftiga snöbyar", "Heavy snow showers", "snow-showers-heavy", true},
+	18: {"Lätt regn", "Light rain", "rain-light", false},
+	19: {"Måttligt regn", "Moderate rain", "rain", false},
+	20: {"Kraftigt regn", "Heavy rain", "rain-heavy", false},
+	21: {"Åska", "Thunder", "thunderstorm", false},
+	22: {"Lätt snöblandat regn", "Light sleet", "sleet-light", false},
+	23: {"Måttligt snöblandat regn", "Moderate sleet", "sleet", false},
+	24: {"Kraftigt snöblandat regn", "Heavy sleet", "sleet-heavy", false},
+	25: {"Lätt snöfall", "Light snowfall", "snow-light", false},
+	26: {"Måttligt snöfall", "Moderate snowfall", "snow", false},
+	27: {"Kraftigt snöfall", "Heavy snowfall", "snow-heavy", false},
+}
+
+// Valid reports whether the code is a known weather symbol
+func (w WeatherSymbol) Valid() bool {
+	_, ok := weatherSymbolDescriptions[w]
+	return ok
+}
+
+// Swedish returns the Swedish description of the symbol
+func (w WeatherSymbol) Swedish() string {
+	if d, ok := weatherSymbolDescriptions[w]; ok {
+		return d.swedish
+	}
+	return fmt.Sprintf("Okänd vädersymbol %d", int(w))
+}
+
+// English returns the English description of the symbol
+func (w WeatherSymbol) English() string {
+	if d, ok := weatherSymbolDescriptions[w]; ok {
+		return d.english
+	}
+	return fmt.Sprintf("Unknown weather symbol %d", int(w))
+}
+
+// String implements the Stringer interface
+func (w WeatherSymbol) String() string {
+	return w.English()
+}
+
+// Icon returns the name of the icon for the symbol, such as "clear-day" or
+// "rain", and "unknown" for unknown symbols
+// Symbols where the sky is visible have day and night variants
+func (w WeatherSymbol) Icon(day bool) string {
+	d, ok := weatherSymbolDescriptions[w]
+	if !ok {
+		return "unknown"
+	}
+	if !d.dayNight {
+		return d.icon
+	}
+	if day {
+		return d.icon + "-day"
+	}
+	return d.icon + "-night"
+}
+
+// IconAt returns the name of the icon for the symbol at a time and place,
+// choosing the day or night variant from the position of the sun
+func (w WeatherSymbol) IconAt(t time.Time, lat, lon float64) string {
+	return w.Icon(Daytime(t, lat, lon))
+}
+
+// sunriseElevation is the elevation of the centre of the sun at sunrise and
+// sunset, accounting for refraction and the radius of the sun
+const sunriseElevation = -0.833
+
+// Daytime reports whether the sun is up at a time and place
+func Daytime(t time.Time, lat, lon float64) bool {
+	return SunElevation(t, lat, lon) > sunriseElevation
+}
+
+// SunElevation returns the elevation of the sun in degrees above the horizon
+// at a time and place, accurate to about a degree
+func SunElevation(t time.Time, lat, lon float64) float64 {
+	const rad = math.Pi / 180
+
+	// Days since the J2000 epoch, 2000-01-01 12:00 UTC
+	d := float64(t.UTC().UnixNano())/float64(24*time.Hour) - 10957.5
+
+	meanAnomaly := (357.529 + 0.98560028*d) * rad
+	meanLongitude := 280.459 + 0.98564736*d
+	eclipticLongitude := (meanLongitude + 1.915*math.Sin(meanAnomaly) + 0.020*math.Sin(2*meanAnomaly)) * rad
+	obliquity := (23.439 - 0.00000036*d) * rad
+
+	rightAscension := math.Atan2(math.Cos(obliquity)*math.Sin(eclipticLongitude), math.Cos(eclipticLongitude))
+	declination := math.Asin(math.Sin(obliquity) * math.Sin(eclipticLongitude))
+
+	siderealTime := (280.46061837 + 360.98564736629*d + lon) * rad
+	hourAngle := siderealTime - rightAscension
+
+	elevation := math.Asin(math.Sin(lat*rad)*math.Sin(declination) +
+		math.Cos(lat*rad)*math.Cos(declination)*math.Cos(hourAngle))
+	return elevation / rad
+}
+
+// Icons returns the icon names of the weather symbols of the forecast, with
+// day and night variants chosen for the location of the forecast
+func (f *Forecast) Icons() []string {
+	var lat, lon float64
+	if len(f.Geometry.Coordinates) > 0 && len(f.Geometry.Coordinates[0]) >= 2 {
+		lon, lat = f.Geometry.Coordinates[0][0], f.Geometry.Coordinates[0][1]
+	}
+
+	icons := make([]string, len(f.TimeSeries))
+	for i, t := range f.TimeSeries {
+		icons[i] = t.Values().Symbol.IconAt(t.ValidTime, lat, lon)
+	}
+	return icons
+}
@@ -0,0 +1,58 @@
+package smhi
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWeatherSymbol(t *testing.T) {
+	tests := []struct {
+		symbol  WeatherSymbol
+		swedish string
+		english string
+		day     string
+		night   string
+	}{
+		{1, "Klar himmel", "Clear sky", "clear-day", "clear-night"},
+		{9, "Måttliga regnskurar", "Moderate rain showers", "rain-showers-day", "rain-showers-night"},
+		{27, "Kraftigt snöfall", "Heavy snowfall", "snow-heavy", "snow-heavy"},
+		{0, "Okänd vädersymbol 0", "Unknown weather symbol 0", "unknown", "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.symbol.Swedish(); got != tt.swedish {
+			t.Errorf("WeatherSymbol(%d).Swedish() is %q, want %q", tt.symbol, got, tt.swedish)
+		}
+		if got := tt.symbol.String(); got != tt.english {
+			t.Errorf("WeatherSymbol(%d).String() is %q, want %q", tt.symbol, got, tt.english)
+		}
+		if got := tt.symbol.Icon(true); got != tt.day {
+			t.Errorf("WeatherSymbol(%d).Icon(true) is %q, want %q", tt.symbol, got, tt.day)
+		}
+		if got := tt.symbol.Icon(false); got != tt.night {
+			t.Errorf("WeatherSymbol(%d).Icon(false) is %q, want %q", tt.symbol, got, tt.night)
+		}
+	}
+}
+
+func TestSunElevation(t *testing.T) {
+	const lat, lon = 59.3293, 18.0686 // Stockholm
+
+	// Solar noon at midsummer, when the elevation is 90 - lat + 23.44
+	noon := time.Date(2020, 6, 20, 10, 50, 0, 0, time.UTC)
+	if got, want := SunElevation(noon, lat, lon), 90-lat+23.44; math.Abs(got-want) > 1 {
+		t.Errorf("SunElevation at midsummer noon is %v, want %v", got, want)
+	}
+
+	if !Daytime(noon, lat, lon) {
+		t.Errorf("Daytime at midsummer noon is false")
+	}
+	if midnight := time.Date(2020, 12, 21, 23, 0, 0, 0, time.UTC); Daytime(midnight, lat, lon) {
+		t.Errorf("Daytime at midwinter midnight is true")
+	}
+
+	if got, want := WeatherSymbol(1).IconAt(noon, lat, lon), "clear-day"; got != want {
+		t.Errorf("IconAt midsummer noon is %q, want %q", got, want)
+	}
+}
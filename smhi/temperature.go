@@ -2,8 +2,8 @@ package smhi
 
 import (
 	"context"
-	"fmt"
 	"net/http"
+	"time"
 )
 
 // Temperature parameter definitions
@@ -13,121 +13,101 @@ const (
 	TemperatureParameterMinimumDaily      = 19
 	TemperatureParameterMaximumDaily      = 20
 	TemperatureParameterAverageMonthly    = 22
-	TemperatureParameterMinimumTwiceDaily = 26 // Not implemented
-	TemperatureParameterMaximumTwiceDaily = 27 // Not implemented
+	TemperatureParameterMinimumTwiceDaily = 26
+	TemperatureParameterMaximumTwiceDaily = 27
 )
 
 // TemperatureService is a service for the temperature queries
 type TemperatureService service
 
 // TemperatureData hold the returned data
-type TemperatureData struct {
-	Value     []TemperatureDataValue `json:"value,omitempty"`
-	Updated   uint64                 `json:"updated,omitempty"`
-	Parameter ParameterData          `json:"parameter,omitempty"`
-	Station   StationData            `json:"station,omitempty"`
-	Period    PeriodData             `json:"period,omitempty"`
-	Position  []PositionData         `json:"position,omitempty"`
-}
+type TemperatureData = ObservationData
 
 // TemperatureDataValue holds value data for temperatures
-type TemperatureDataValue struct {
-	From    uint64 `json:"from,omitempty"`
-	To      uint64 `json:"to,omitempty"`
-	Ref     string `json:"ref,omitempty"`
-	Value   string `json:"value,omitempty"`
-	Quality string `json:"quality,omitempty"`
-}
-
-func getTemperatureData(ctx context.Context, client *Client, parameter int, station uint32, period string) (*TemperatureData, *http.Response, error) {
-	dataURL := fmt.Sprintf("api/version/latest/parameter/%d/station/%d/period/%s/data.json", parameter, station, period)
-	req, err := client.NewRequest("GET", dataURL)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	td := &TemperatureData{}
-	resp, err := client.Do(ctx, req, td)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return td, resp, nil
-}
-
-func getParameterData(ctx context.Context, client *Client, parameter int, includeInactive bool) (*Parameter, *http.Response, error) {
-	dataURL := fmt.Sprintf("api/version/latest/parameter/%d.json", parameter)
-	req, err := client.NewRequest("GET", dataURL)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	p := &Parameter{}
-	resp, err := client.Do(ctx, req, p)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	// Filter out the inactive stations
-	if !includeInactive {
-		newStation := make([]Station, 0)
-		for _, s := range p.Station {
-			if s.Active {
-				newStation = append(newStation, s)
-			}
+type TemperatureDataValue = ObservationValue
+
+// TwiceDailyInterval returns the 12 hour reporting window a twice daily value is attributed to
+// The windows end at 06 and 18 UTC, and a value belongs to the first window ending at or after its time
+func TwiceDailyInterval(v TemperatureDataValue) (from, to time.Time) {
+	t := v.Time()
+	day := t.Truncate(24 * time.Hour)
+
+	for _, h := range []time.Duration{6, 18, 30} {
+		to = day.Add(h * time.Hour)
+		if !to.Before(t) {
+			break
 		}
-		p.Station = newStation
 	}
 
-	return p, resp, nil
+	return to.Add(-12 * time.Hour), to
 }
 
 // GetHourlyTemperatures retrieves hourly temperatures from a station
-func (s *TemperatureService) GetHourlyTemperatures(ctx context.Context, station uint32, period string) (*TemperatureData, *http.Response, error) {
-	return getTemperatureData(ctx, s.client, TemperatureParameterHourly, station, period)
+func (s *TemperatureService) GetHourlyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterHourly, station, period, opts...)
 }
 
 // GetStationsWithHourlyTemperatures retrives all stations with hourly temperatures
 func (s *TemperatureService) GetStationsWithHourlyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
-	return getParameterData(ctx, s.client, TemperatureParameterHourly, includeInactive)
+	return getParameterData(ctx, (*service)(s), TemperatureParameterHourly, includeInactive)
 }
 
 // GetAverageDailyTemperatures retrieves the average daily temperatures from a station
-func (s *TemperatureService) GetAverageDailyTemperatures(ctx context.Context, station uint32, period string) (*TemperatureData, *http.Response, error) {
-	return getTemperatureData(ctx, s.client, TemperatureParameterAverageDaily, station, period)
+func (s *TemperatureService) GetAverageDailyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterAverageDaily, station, period, opts...)
 }
 
 // GetStationsWithAverageDailyTemperatures retrieves all stations with average daily temperatures
 func (s *TemperatureService) GetStationsWithAverageDailyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
-	return getParameterData(ctx, s.client, TemperatureParameterAverageDaily, includeInactive)
+	return getParameterData(ctx, (*service)(s), TemperatureParameterAverageDaily, includeInactive)
 }
 
 // GetAverageMonthlyTemperatures retrieves the average monthly temperatures from a station
-func (s *TemperatureService) GetAverageMonthlyTemperatures(ctx context.Context, station uint32, period string) (*TemperatureData, *http.Response, error) {
-	return getTemperatureData(ctx, s.client, TemperatureParameterAverageMonthly, station, period)
+func (s *TemperatureService) GetAverageMonthlyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterAverageMonthly, station, period, opts...)
 }
 
 // GetStationsWithAverageMonthlyTemperatures retrieves all stations with average daily temperatures
 func (s *TemperatureService) GetStationsWithAverageMonthlyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
-	return getParameterData(ctx, s.client, TemperatureParameterAverageMonthly, includeInactive)
+	return getParameterData(ctx, (*service)(s), TemperatureParameterAverageMonthly, includeInactive)
 }
 
 // GetMinimumDailyTemperatures retrieves the minimum daily temperatures from a station
-func (s *TemperatureService) GetMinimumDailyTemperatures(ctx context.Context, station uint32, period string) (*TemperatureData, *http.Response, error) {
-	return getTemperatureData(ctx, s.client, TemperatureParameterMinimumDaily, station, period)
+func (s *TemperatureService) GetMinimumDailyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterMinimumDaily, station, period, opts...)
 }
 
 // GetStationsWithMinimumDailyTemperatures retrieves all stations with minimum daily temperatures
 func (s *TemperatureService) GetStationsWithMinimumDailyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
-	return getParameterData(ctx, s.client, TemperatureParameterMinimumDaily, includeInactive)
+	return getParameterData(ctx, (*service)(s), TemperatureParameterMinimumDaily, includeInactive)
 }
 
 // GetMaximumDailyTemperatures retrieves the maximum daily temperatures from a station
-func (s *TemperatureService) GetMaximumDailyTemperatures(ctx context.Context, station uint32, period string) (*TemperatureData, *http.Response, error) {
-	return getTemperatureData(ctx, s.client, TemperatureParameterMaximumDaily, station, period)
+func (s *TemperatureService) GetMaximumDailyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterMaximumDaily, station, period, opts...)
 }
 
 // GetStationsWithMaximumDailyTemperatures retrieves all stations with maximum daily temperatures
 func (s *TemperatureService) GetStationsWithMaximumDailyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
-	return getParameterData(ctx, s.client, TemperatureParameterMaximumDaily, includeInactive)
+	return getParameterData(ctx, (*service)(s), TemperatureParameterMaximumDaily, includeInactive)
+}
+
+// GetMinimumTwiceDailyTemperatures retrieves the minimum temperatures reported at 06 and 18 UTC from a station
+func (s *TemperatureService) GetMinimumTwiceDailyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterMinimumTwiceDaily, station, period, opts...)
+}
+
+// GetStationsWithMinimumTwiceDailyTemperatures retrieves all stations with minimum temperatures reported at 06 and 18 UTC
+func (s *TemperatureService) GetStationsWithMinimumTwiceDailyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), TemperatureParameterMinimumTwiceDaily, includeInactive)
+}
+
+// GetMaximumTwiceDailyTemperatures retrieves the maximum temperatures reported at 06 and 18 UTC from a station
+func (s *TemperatureService) GetMaximumTwiceDailyTemperatures(ctx context.Context, station uint32, period string, opts ...DataOption) (*TemperatureData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), TemperatureParameterMaximumTwiceDaily, station, period, opts...)
+}
+
+// GetStationsWithMaximumTwiceDailyTemperatures retrieves all stations with maximum temperatures reported at 06 and 18 UTC
+func (s *TemperatureService) GetStationsWithMaximumTwiceDailyTemperatures(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), TemperatureParameterMaximumTwiceDaily, includeInactive)
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestTemperatureService_GetAverageDailyTemperatures_returnsOK(t *testing.T) {
@@ -1221,3 +1222,110 @@ func TestTemperatureService_GetStationsWithAverageMonthlyTemperatures_returnsOK(
 		}
 	})
 }
+
+func TestTemperatureService_GetMinimumTwiceDailyTemperatures_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/26/station/12345/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"from": 1533276001000, "to": 1533319200000, "ref": "2018-08-03", "value": "14.2", "quality": "Y"}],
+		"updated": 1533470400000,
+		"parameter": {
+		"key": "26",
+		"name": "Lufttemperatur",
+		"summary": "min, 2 gånger per dygn, kl 06 och 18",
+		"unit": "degree celsius"
+		},
+		"station": {
+		"key": "97100",
+		"name": "Tullinge A",
+		"owner": "SMHI",
+		"height": 2.0
+		}}`)
+	})
+
+	temps, _, err := client.Temperatures.GetMinimumTwiceDailyTemperatures(context.Background(), 12345, PeriodLatestDay)
+	if err != nil {
+		t.Errorf("Temperatures.GetMinimumTwiceDailyTemperatures returned error: %v", err)
+	}
+
+	want := &TemperatureData{
+		Value: []TemperatureDataValue{
+			{
+				From:    1533276001000,
+				To:      1533319200000,
+				Ref:     "2018-08-03",
+				Value:   "14.2",
+				Quality: "Y",
+			},
+		},
+		Updated: 1533470400000,
+		Parameter: ParameterData{
+			Key:     "26",
+			Name:    "Lufttemperatur",
+			Summary: "min, 2 gånger per dygn, kl 06 och 18",
+			Unit:    "degree celsius",
+		},
+		Station: StationData{
+			Key:    "97100",
+			Name:   "Tullinge A",
+			Owner:  "SMHI",
+			Height: 2.0,
+		},
+	}
+	if !reflect.DeepEqual(temps, want) {
+		t.Errorf("Temperatures.GetMinimumTwiceDailyTemperatures returned %+v, want %+v", temps, want)
+	}
+}
+
+func TestTemperatureService_GetMaximumTwiceDailyTemperatures_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/27/station/12345/period/latest-day/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Temperatures.GetMaximumTwiceDailyTemperatures(context.Background(), 12346, PeriodLatestDay)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Temperatures.GetMaximumTwiceDailyTemperatures returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
+
+func TestTwiceDailyInterval(t *testing.T) {
+	tests := []struct {
+		name  string
+		value TemperatureDataValue
+		from  time.Time
+		to    time.Time
+	}{
+		{
+			name:  "interval ending at 18",
+			value: TemperatureDataValue{From: 1533276001000, To: 1533319200000},
+			from:  time.Date(2018, 8, 3, 6, 0, 0, 0, time.UTC),
+			to:    time.Date(2018, 8, 3, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "interval ending at 06",
+			value: TemperatureDataValue{From: 1533319201000, To: 1533362400000},
+			from:  time.Date(2018, 8, 3, 18, 0, 0, 0, time.UTC),
+			to:    time.Date(2018, 8, 4, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "sampled after 18",
+			value: TemperatureDataValue{Date: 1533322800000},
+			from:  time.Date(2018, 8, 3, 18, 0, 0, 0, time.UTC),
+			to:    time.Date(2018, 8, 4, 6, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := TwiceDailyInterval(tt.value)
+			if !from.Equal(tt.from) || !to.Equal(tt.to) {
+				t.Errorf("TwiceDailyInterval returned %v - %v, want %v - %v", from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
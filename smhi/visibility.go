@@ -0,0 +1,52 @@
+package smhi
+
+import (
+	"context"
+	"net/http"
+)
+
+// Visibility parameter definitions
+const (
+	VisibilityParameterHourly = 12
+)
+
+// VisibilityService is a service for the visibility queries
+type VisibilityService service
+
+// Visibility is a visibility in metres
+type Visibility float64
+
+// VisibilityData holds the returned visibility data
+type VisibilityData struct {
+	ObservationData
+}
+
+// Visibilities returns the values as visibilities
+func (d *VisibilityData) Visibilities() ([]Visibility, error) {
+	values, err := parseValues(d.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	visibilities := make([]Visibility, len(values))
+	for i, v := range values {
+		visibilities[i] = Visibility(v)
+	}
+
+	return visibilities, nil
+}
+
+// GetHourlyVisibilities retrieves the hourly visibilities from a station
+func (s *VisibilityService) GetHourlyVisibilities(ctx context.Context, station uint32, period string, opts ...DataOption) (*VisibilityData, *http.Response, error) {
+	od, resp, err := getObservationData(ctx, (*service)(s), VisibilityParameterHourly, station, period, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &VisibilityData{ObservationData: *od}, resp, nil
+}
+
+// GetStationsWithHourlyVisibilities retrieves all stations with hourly visibilities
+func (s *VisibilityService) GetStationsWithHourlyVisibilities(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), VisibilityParameterHourly, includeInactive)
+}
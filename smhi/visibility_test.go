@@ -0,0 +1,88 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestVisibilityService_GetHourlyVisibilities_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/12/station/97400/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [
+		{"date": 1533463200000, "value": "20000", "quality": "G"},
+		{"date": 1533466800000, "value": "", "quality": "G"}
+		],
+		"updated": 1533466800000,
+		"parameter": {
+		"key": "12",
+		"name": "Sikt",
+		"summary": "momentanvärde, 1 gång/tim",
+		"unit": "meter"
+		},
+		"station": {
+		"key": "97400",
+		"name": "Stockholm-Arlanda Flygplats",
+		"owner": "SMHI",
+		"height": 32.0
+		}}`)
+	})
+
+	visibility, _, err := client.Visibility.GetHourlyVisibilities(context.Background(), 97400, PeriodLatestHour)
+	if err != nil {
+		t.Fatalf("Visibility.GetHourlyVisibilities returned error: %v", err)
+	}
+
+	want := &VisibilityData{
+		ObservationData: ObservationData{
+			Value: []ObservationValue{
+				{Date: 1533463200000, Value: "20000", Quality: "G"},
+				{Date: 1533466800000, Value: "", Quality: "G"},
+			},
+			Updated: 1533466800000,
+			Parameter: ParameterData{
+				Key:     "12",
+				Name:    "Sikt",
+				Summary: "momentanvärde, 1 gång/tim",
+				Unit:    "meter",
+			},
+			Station: StationData{
+				Key:    "97400",
+				Name:   "Stockholm-Arlanda Flygplats",
+				Owner:  "SMHI",
+				Height: 32.0,
+			},
+		},
+	}
+	if !reflect.DeepEqual(visibility, want) {
+		t.Errorf("Visibility.GetHourlyVisibilities returned %+v, want %+v", visibility, want)
+	}
+
+	visibilities, err := visibility.Visibilities()
+	if err != nil {
+		t.Fatalf("VisibilityData.Visibilities returned error: %v", err)
+	}
+	if len(visibilities) != 2 || visibilities[0] != 20000 || !math.IsNaN(float64(visibilities[1])) {
+		t.Errorf("VisibilityData.Visibilities returned %v, want [20000 NaN]", visibilities)
+	}
+}
+
+func TestVisibilityService_GetHourlyVisibilities_returns404(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/12/station/12345/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {})
+
+	_, resp, err := client.Visibility.GetHourlyVisibilities(context.Background(), 12346, PeriodLatestHour)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Visibility.GetHourlyVisibilities returned error code %d, expected %d", resp.StatusCode, http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Present weather parameter definitions
+const (
+	PresentWeatherParameterHourly = 13
+)
+
+// PresentWeatherService is a service for the present weather queries
+type PresentWeatherService service
+
+// PresentWeather is a WMO present weather code
+// Codes 0-99 are reported by manned stations (WMO table 4677) and codes
+// 100-199 by automatic stations (WMO table 4680 offset by 100)
+type PresentWeather int
+
+// PresentWeatherAutomaticOffset is the offset for codes reported by automatic stations
+const PresentWeatherAutomaticOffset = 100
+
+// PresentWeatherMissing is the code returned for missing values
+const PresentWeatherMissing PresentWeather = -1
+
+type presentWeatherDescription struct {
+	swedish string
+	english string
+}
+
+var presentWeatherDescriptions = map[PresentWeather]presentWeatherDescription{
+	0:  {"Molnutveckling ej observerad eller ej observerbar", "Cloud development not observed or not observable"},
+	1:  {"Molnen upplöses eller blir mindre utvecklade", "Clouds generally dissolving or becoming less developed"},
+	2:  {"Himlens utseende i stort sett oförändrat", "State of sky on the whole unchanged"},
+	3:  {"Moln bildas eller utvecklas", "Clouds generally forming or developing"},
+	4:  {"Sikten nedsatt av rök", "Visibility reduced by smoke"},
+	5:  {"Torrdis", "Haze"},
+	6:  {"Utbrett damm i luften, ej uppvirvlat av vind", "Widespread dust in suspension in the air, not raised by wind"},
+	7:  {"Damm eller sand uppvirvlat av vind", "Dust or sand raised by wind"},
+	8:  {"Väl utvecklade damm- eller sandvirvlar", "Well developed dust or sand whirls"},
+	9:  {"Damm- eller sandstorm inom synhåll eller under senaste timmen", "Duststorm or sandstorm within sight or during the preceding hour"},
+	10: {"Fuktdis", "Mist"},
+	11: {"Låg dimma i bankar", "Patches of shallow fog or ice fog"},
+	12: {"Mer eller mindre sammanhängande låg dimma", "More or less continuous shallow fog or ice fog"},
+	13: {"Blixt synlig, ingen åska hörd", "Lightning visible, no thunder heard"},
+	14: {"Nederbörd inom synhåll, når ej marken", "Precipitation within sight, not reaching the ground"},
+	15: {"Nederbörd inom synhåll, når marken, långt från stationen", "Precipitation within sight, reaching the ground, distant from the station"},
+	16: {"Nederbörd inom synhåll, når marken, nära men ej vid stationen", "Precipitation within sight, reaching the ground, near to but not at the station"},
+	17: {"Åska utan nederbörd", "Thunderstorm, but no precipitation at the time of observation"},
+	18: {"Byar", "Squalls"},
+	19: {"Tromb", "Funnel clouds"},
+	20: {"Duggregn eller kornsnö under senaste timmen", "Drizzle or snow grains during the preceding hour"},
+	21: {"Regn under senaste timmen", "Rain during the preceding hour"},
+	22: {"Snöfall under senaste timmen", "Snow during the preceding hour"},
+	23: {"Snöblandat regn eller iskorn under senaste timmen", "Rain and snow or ice pellets during the preceding hour"},
+	24: {"Underkylt duggregn eller regn under senaste timmen", "Freezing drizzle or freezing rain during the preceding hour"},
+	25: {"Regnskurar under senaste timmen", "Shower(s) of rain during the preceding hour"},
+	26: {"Snöbyar eller byar av snöblandat regn under senaste timmen", "Shower(s) of snow, or of rain and snow during the preceding hour"},
+	27: {"Hagelbyar under senaste timmen", "Shower(s) of hail, or of rain and hail during the preceding hour"},
+	28: {"Dimma under senaste timmen", "Fog or ice fog during the preceding hour"},
+	29: {"Åska under senaste timmen", "Thunderstorm during the preceding hour"},
+	30: {"Lätt eller måttlig damm- eller sandstorm, avtagande", "Slight or moderate duststorm or sandstorm, has decreased"},
+	31: {"Lätt eller måttlig damm- eller sandstorm, oförändrad", "Slight or moderate duststorm or sandstorm, no appreciable change"},
+	32: {"Lätt eller måttlig damm- eller sandstorm, tilltagande", "Slight or moderate duststorm or sandstorm, has begun or increased"},
+	33: {"Kraftig damm- eller sandstorm, avtagande", "Severe duststorm or sandstorm, has decreased"},
+	34: {"Kraftig damm- eller sandstorm, oförändrad", "Severe duststorm or sandstorm, no appreciable change"},
+	35: {"Kraftig damm- eller sandstorm, tilltagande", "Severe duststorm or sandstorm, has begun or increased"},
+	36: {"Lätt eller måttligt lågt snödrev", "Slight or moderate drifting snow, generally low"},
+	37: {"Kraftigt lågt snödrev", "Heavy drifting snow, generally low"},
+	38: {"Lätt eller måttligt högt snödrev", "Slight or moderate blowing snow, generally high"},
+	39: {"Kraftigt högt snödrev", "Heavy blowing snow, generally high"},
+	40: {"Dimma på avstånd", "Fog or ice fog at a distance"},
+	41: {"Dimbankar", "Fog or ice fog in patches"},
+	42: {"Dimma, himlen synlig, har tunnats ut", "Fog or ice fog, sky visible, has become thinner"},
+	43: {"Dimma, himlen ej synlig, har tunnats ut", "Fog or ice fog, sky invisible, has become thinner"},
+	44: {"Dimma, himlen synlig, oförändrad", "Fog or ice fog, sky visible, no appreciable change"},
+	45: {"Dimma, himlen ej synlig, oförändrad", "Fog or ice fog, sky invisible, no appreciable change"},
+	46: {"Dimma, himlen synlig, har börjat eller tätnat", "Fog or ice fog, sky visible, has begun or become thicker"},
+	47: {"Dimma, himlen ej synlig, har börjat eller tätnat", "Fog or ice fog, sky invisible, has begun or become thicker"},
+	48: {"Underkyld dimma, himlen synlig", "Fog, depositing rime, sky visible"},
+	49: {"Underkyld dimma, himlen ej synlig", "Fog, depositing rime, sky invisible"},
+	50: {"Lätt duggregn med uppehåll", "Drizzle, not freezing, intermittent, slight"},
+	51: {"Lätt duggregn", "Drizzle, not freezing, continuous, slight"},
+	52: {"Måttligt duggregn med uppehåll", "Drizzle, not freezing, intermittent, moderate"},
+	53: {"Måttligt duggregn", "Drizzle, not freezing, continuous, moderate"},
+	54: {"Kraftigt duggregn med uppehåll", "Drizzle, not freezing, intermittent, heavy"},
+	55: {"Kraftigt duggregn", "Drizzle, not freezing, continuous, heavy"},
+	56: {"Lätt underkylt duggregn", "Drizzle, freezing, slight"},
+	57: {"Måttligt eller kraftigt underkylt duggregn", "Drizzle, freezing, moderate or heavy"},
+	58: {"Lätt duggregn och regn", "Drizzle and rain, slight"},
+	59: {"Måttligt eller kraftigt duggregn och regn", "Drizzle and rain, moderate or heavy"},
+	60: {"Lätt regn med uppehåll", "Rain, not freezing, intermittent, slight"},
+	61: {"Lätt regn", "Rain, not freezing, continuous, slight"},
+	62: {"Måttligt regn med uppehåll", "Rain, not freezing, intermittent, moderate"},
+	63: {"Måttligt regn", "Rain, not freezing, continuous, moderate"},
+	64: {"Kraftigt regn med uppehåll", "Rain, not freezing, intermittent, heavy"},
+	65: {"Kraftigt regn", "Rain, not freezing, continuous, heavy"},
+	66: {"Lätt underkylt regn", "Rain, freezing, slight"},
+	67: {"Måttligt eller kraftigt underkylt regn", "Rain, freezing, moderate or heavy"},
+	68: {"Lätt snöblandat regn", "Rain or drizzle and snow, slight"},
+	69: {"Måttligt eller kraftigt snöblandat regn", "Rain or drizzle and snow, moderate or heavy"},
+	70: {"Lätt snöfall med uppehåll", "Snow, intermittent, slight"},
+	71: {"Lätt snöfall", "Snow, continuous, slight"},
+	72: {"Måttligt snöfall med uppehåll", "Snow, intermittent, moderate"},
+	73: {"Måttligt snöfall", "Snow, continuous, moderate"},
+	74: {"Kraftigt snöfall med uppehåll", "Snow, intermittent, heavy"},
+	75: {"Kraftigt snöfall", "Snow, continuous, heavy"},
+	76: {"Isnålar", "Diamond dust"},
+	77: {"Kornsnö", "Snow grains"},
+	78: {"Enstaka stjärnformiga snökristaller", "Isolated star-like snow crystals"},
+	79: {"Iskorn", "Ice pellets"},
+	80: {"Lätta regnskurar", "Rain shower(s), slight"},
+	81: {"Måttliga eller kraftiga regnskurar", "Rain shower(s), moderate or heavy"},
+	82: {"Mycket kraftiga regnskurar", "Rain shower(s), violent"},
+	83: {"Lätta byar av snöblandat regn", "Shower(s) of rain and snow mixed, slight"},
+	84: {"Måttliga eller kraftiga byar av snöblandat regn", "Shower(s) of rain and snow mixed, moderate or heavy"},
+	85: {"Lätta snöbyar", "Snow shower(s), slight"},
+	86: {"Måttliga eller kraftiga snöbyar", "Snow shower(s), moderate or heavy"},
+	87: {"Lätta byar av snöhagel eller småhagel", "Shower(s) of snow pellets or small hail, slight"},
+	88: {"Måttliga eller kraftiga byar av snöhagel eller småhagel", "Shower(s) of snow pellets or small hail, moderate or heavy"},
+	89: {"Lätta hagelbyar", "Shower(s) of hail, slight"},
+	90: {"Måttliga eller kraftiga hagelbyar", "Shower(s) of hail, moderate or heavy"},
+	91: {"Lätt regn, åska under senaste timmen", "Slight rain, thunderstorm during the preceding hour"},
+	92: {"Måttligt eller kraftigt regn, åska under senaste timmen", "Moderate or heavy rain, thunderstorm during the preceding hour"},
+	93: {"Lätt snö, snöblandat regn eller hagel, åska under senaste timmen", "Slight snow, rain and snow mixed or hail, thunderstorm during the preceding hour"},
+	94: {"Måttlig eller kraftig snö, snöblandat regn eller hagel, åska under senaste timmen", "Moderate or heavy snow, rain and snow mixed or hail, thunderstorm during the preceding hour"},
+	95: {"Lätt eller måttlig åska med regn eller snö", "Thunderstorm, slight or moderate, with rain and/or snow"},
+	96: {"Lätt eller måttlig åska med hagel", "Thunderstorm, slight or moderate, with hail"},
+	97: {"Kraftig åska med regn eller snö", "Thunderstorm, heavy, with rain and/or snow"},
+	98: {"Åska med damm- eller sandstorm", "Thunderstorm combined with duststorm or sandstorm"},
+	99: {"Kraftig åska med hagel", "Thunderstorm, heavy, with hail"},
+
+	100: {"Inget väsentligt väder observerat", "No significant weather observed"},
+	101: {"Molnen upplöses under senaste timmen", "Clouds generally dissolving during the past hour"},
+	102: {"Himlens utseende oförändrat under senaste timmen", "State of sky on the whole unchanged during the past hour"},
+	103: {"Moln bildas under senaste timmen", "Clouds generally forming during the past hour"},
+	104: {"Dis, rök eller damm, sikt minst 1 km", "Haze, smoke or dust in suspension, visibility 1 km or more"},
+	105: {"Dis, rök eller damm, sikt under 1 km", "Haze, smoke or dust in suspension, visibility less than 1 km"},
+	110: {"Fuktdis", "Mist"},
+	111: {"Isnålar", "Diamond dust"},
+	112: {"Blixt på avstånd", "Distant lightning"},
+	118: {"Byar", "Squalls"},
+	120: {"Dimma under senaste timmen", "Fog during the past hour"},
+	121: {"Nederbörd under senaste timmen", "Precipitation during the past hour"},
+	122: {"Duggregn eller kornsnö under senaste timmen", "Drizzle or snow grains during the past hour"},
+	123: {"Regn under senaste timmen", "Rain during the past hour"},
+	124: {"Snöfall under senaste timmen", "Snow during the past hour"},
+	125: {"Underkylt duggregn eller regn under senaste timmen", "Freezing drizzle or freezing rain during the past hour"},
+	126: {"Åska under senaste timmen", "Thunderstorm during the past hour"},
+	127: {"Snödrev eller sanddrev", "Blowing or drifting snow or sand"},
+	128: {"Snödrev eller sanddrev, sikt minst 1 km", "Blowing or drifting snow or sand, visibility 1 km or more"},
+	129: {"Snödrev eller sanddrev, sikt under 1 km", "Blowing or drifting snow or sand, visibility less than 1 km"},
+	130: {"Dimma", "Fog"},
+	131: {"Dimbankar", "Fog or ice fog in patches"},
+	132: {"Dimma, har tunnats ut under senaste timmen", "Fog or ice fog, has become thinner during the past hour"},
+	133: {"Dimma, oförändrad under senaste timmen", "Fog or ice fog, no appreciable change during the past hour"},
+	134: {"Dimma, har börjat eller tätnat under senaste timmen", "Fog or ice fog, has begun or become thicker during the past hour"},
+	135: {"Underkyld dimma", "Fog, depositing rime"},
+	140: {"Nederbörd", "Precipitation"},
+	141: {"Lätt eller måttlig nederbörd", "Precipitation, slight or moderate"},
+	142: {"Kraftig nederbörd", "Precipitation, heavy"},
+	143: {"Lätt eller måttlig flytande nederbörd", "Liquid precipitation, slight or moderate"},
+	144: {"Kraftig flytande nederbörd", "Liquid precipitation, heavy"},
+	145: {"Lätt eller måttlig fast nederbörd", "Solid precipitation, slight or moderate"},
+	146: {"Kraftig fast nederbörd", "Solid precipitation, heavy"},
+	147: {"Lätt eller måttlig underkyld nederbörd", "Freezing precipitation, slight or moderate"},
+	148: {"Kraftig underkyld nederbörd", "Freezing precipitation, heavy"},
+	150: {"Duggregn", "Drizzle"},
+	151: {"Lätt duggregn", "Drizzle, not freezing, slight"},
+	152: {"Måttligt duggregn", "Drizzle, not freezing, moderate"},
+	153: {"Kraftigt duggregn", "Drizzle, not freezing, heavy"},
+	154: {"Lätt underkylt duggregn", "Drizzle, freezing, slight"},
+	155: {"Måttligt underkylt duggregn", "Drizzle, freezing, moderate"},
+	156: {"Kraftigt underkylt duggregn", "Drizzle, freezing, heavy"},
+	157: {"Lätt duggregn och regn", "Drizzle and rain, slight"},
+	158: {"Måttligt eller kraftigt duggregn och regn", "Drizzle and rain, moderate or heavy"},
+	160: {"Regn", "Rain"},
+	161: {"Lätt regn", "Rain, not freezing, slight"},
+	162: {"Måttligt regn", "Rain, not freezing, moderate"},
+	163: {"Kraftigt regn", "Rain, not freezing, heavy"},
+	164: {"Lätt underkylt regn", "Rain, freezing, slight"},
+	165: {"Måttligt underkylt regn", "Rain, freezing, moderate"},
+	166: {"Kraftigt underkylt regn", "Rain, freezing, heavy"},
+	167: {"Lätt snöblandat regn", "Rain or drizzle and snow, slight"},
+	168: {"Måttligt eller kraftigt snöblandat regn", "Rain or drizzle and snow, moderate or heavy"},
+	170: {"Snöfall", "Snow"},
+	171: {"Lätt snöfall", "Snow, slight"},
+	172: {"Måttligt snöfall", "Snow, moderate"},
+	173: {"Kraftigt snöfall", "Snow, heavy"},
+	174: {"Lätt iskornsfall", "Ice pellets, slight"},
+	175: {"Måttligt iskornsfall", "Ice pellets, moderate"},
+	176: {"Kraftigt iskornsfall", "Ice pellets, heavy"},
+	177: {"Kornsnö", "Snow grains"},
+	178: {"Iskristaller", "Ice crystals"},
+	180: {"Skurar eller nederbörd med uppehåll", "Shower(s) or intermittent precipitation"},
+	181: {"Lätta regnskurar", "Rain shower(s) or intermittent rain, slight"},
+	182: {"Måttliga regnskurar", "Rain shower(s) or intermittent rain, moderate"},
+	183: {"Kraftiga regnskurar", "Rain shower(s) or intermittent rain, heavy"},
+	184: {"Mycket kraftiga regnskurar", "Rain shower(s) or intermittent rain, violent"},
+	185: {"Lätta snöbyar", "Snow shower(s) or intermittent snow, slight"},
+	186: {"Måttliga snöbyar", "Snow shower(s) or intermittent snow, moderate"},
+	187: {"Kraftiga snöbyar", "Snow shower(s) or intermittent snow, heavy"},
+	189: {"Hagel", "Hail"},
+	190: {"Åska", "Thunderstorm"},
+	191: {"Lätt eller måttlig åska utan nederbörd", "Thunderstorm, slight or moderate, with no precipitation"},
+	192: {"Lätt eller måttlig åska med regn- eller snöbyar", "Thunderstorm, slight or moderate, with rain showers and/or snow showers"},
+	193: {"Lätt eller måttlig åska med hagel", "Thunderstorm, slight or moderate, with hail"},
+	194: {"Kraftig åska utan nederbörd", "Thunderstorm, heavy, with no precipitation"},
+	195: {"Kraftig åska med regn- eller snöbyar", "Thunderstorm, heavy, with rain showers and/or snow showers"},
+	196: {"Kraftig åska med hagel", "Thunderstorm, heavy, with hail"},
+	199: {"Tromb", "Tornado"},
+}
+
+// Valid reports whether the code is a known present weather code
+func (w PresentWeather) Valid() bool {
+	_, ok := presentWeatherDescriptions[w]
+	return ok
+}
+
+// Automatic reports whether the code is reported by an automatic station
+func (w PresentWeather) Automatic() bool {
+	return w >= PresentWeatherAutomaticOffset
+}
+
+// Swedish returns the Swedish description of the code
+func (w PresentWeather) Swedish() string {
+	if d, ok := presentWeatherDescriptions[w]; ok {
+		return d.swedish
+	}
+	if w == PresentWeatherMissing {
+		return "Väderkod saknas"
+	}
+	return fmt.Sprintf("Okänd väderkod %d", int(w))
+}
+
+// English returns the English description of the code
+func (w PresentWeather) English() string {
+	if d, ok := presentWeatherDescriptions[w]; ok {
+		return d.english
+	}
+	if w == PresentWeatherMissing {
+		return "Missing weather code"
+	}
+	return fmt.Sprintf("Unknown weather code %d", int(w))
+}
+
+// String implements the Stringer interface
+func (w PresentWeather) String() string {
+	return w.English()
+}
+
+// PresentWeatherData holds the returned present weather data
+type PresentWeatherData struct {
+	ObservationData
+}
+
+// Codes returns the values as present weather codes
+// Codes may be given with decimals, such as "3.0", but must be integral, missing
+// values are returned as PresentWeatherMissing
+func (d *PresentWeatherData) Codes() ([]PresentWeather, error) {
+	codes := make([]PresentWeather, len(d.Value))
+	for i, v := range d.Value {
+		if v.Missing() {
+			codes[i] = PresentWeatherMissing
+			continue
+		}
+		c, err := strconv.ParseFloat(strings.TrimSpace(v.Value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid present weather code %q: %v", v.Value, err)
+		}
+		if math.IsInf(c, 0) || c != math.Trunc(c) {
+			return nil, fmt.Errorf("invalid present weather code %q: not an integer", v.Value)
+		}
+		codes[i] = PresentWeather(c)
+	}
+
+	return codes, nil
+}
+
+// GetHourlyPresentWeather retrieves the hourly present weather from a station
+func (s *PresentWeatherService) GetHourlyPresentWeather(ctx context.Context, station uint32, period string, opts ...DataOption) (*PresentWeatherData, *http.Response, error) {
+	od, resp, err := getObservationData(ctx, (*service)(s), PresentWeatherParameterHourly, station, period, opts...)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &PresentWeatherData{ObservationData: *od}, resp, nil
+}
+
+// GetStationsWithHourlyPresentWeather retrieves all stations with hourly present weather
+func (s *PresentWeatherService) GetStationsWithHourlyPresentWeather(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), PresentWeatherParameterHourly, includeInactive)
+}
@@ -0,0 +1,85 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPresentWeather_descriptions(t *testing.T) {
+	tests := []struct {
+		code      PresentWeather
+		swedish   string
+		english   string
+		automatic bool
+	}{
+		{0, "Molnutveckling ej observerad eller ej observerbar", "Cloud development not observed or not observable", false},
+		{63, "Måttligt regn", "Rain, not freezing, continuous, moderate", false},
+		{171, "Lätt snöfall", "Snow, slight", true},
+		{250, "Okänd väderkod 250", "Unknown weather code 250", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.code.Swedish(); got != tt.swedish {
+			t.Errorf("PresentWeather(%d).Swedish() is %q, want %q", tt.code, got, tt.swedish)
+		}
+		if got := tt.code.English(); got != tt.english {
+			t.Errorf("PresentWeather(%d).English() is %q, want %q", tt.code, got, tt.english)
+		}
+		if got := tt.code.Automatic(); got != tt.automatic {
+			t.Errorf("PresentWeather(%d).Automatic() is %v, want %v", tt.code, got, tt.automatic)
+		}
+	}
+
+	if PresentWeather(250).Valid() {
+		t.Errorf("PresentWeather(250).Valid() is true, want false")
+	}
+}
+
+func TestPresentWeatherService_GetHourlyPresentWeather_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/13/station/97400/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "161", "quality": "G"}],
+		"parameter": {"key": "13", "name": "Rådande väder", "unit": "code"},
+		"station": {"key": "97400", "name": "Stockholm-Arlanda Flygplats", "owner": "SMHI"}}`)
+	})
+
+	weather, _, err := client.PresentWeather.GetHourlyPresentWeather(context.Background(), 97400, PeriodLatestHour)
+	if err != nil {
+		t.Fatalf("PresentWeather.GetHourlyPresentWeather returned error: %v", err)
+	}
+
+	codes, err := weather.Codes()
+	if err != nil {
+		t.Fatalf("PresentWeatherData.Codes returned error: %v", err)
+	}
+	if want := []PresentWeather{161}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("PresentWeatherData.Codes returned %v, want %v", codes, want)
+	}
+}
+
+func TestPresentWeatherData_Codes(t *testing.T) {
+	weather := &PresentWeatherData{ObservationData{Value: []ObservationValue{{Value: "3.0"}, {Value: ""}, {Value: "161"}}}}
+	codes, err := weather.Codes()
+	if err != nil {
+		t.Fatalf("PresentWeatherData.Codes returned error: %v", err)
+	}
+	if want := []PresentWeather{3, PresentWeatherMissing, 161}; !reflect.DeepEqual(codes, want) {
+		t.Errorf("PresentWeatherData.Codes returned %v, want %v", codes, want)
+	}
+	if PresentWeatherMissing.Valid() {
+		t.Errorf("PresentWeatherMissing.Valid() is true, want false")
+	}
+
+	for _, value := range []string{"3.5", "x"} {
+		weather := &PresentWeatherData{ObservationData{Value: []ObservationValue{{Value: value}}}}
+		if _, err := weather.Codes(); err == nil {
+			t.Errorf("PresentWeatherData.Codes expected error for %q", value)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+// Wind parameter definitions
+const (
+	WindParameterDirectionHourly   = 3
+	WindParameterSpeedHourly       = 4
+	WindParameterGustMaximumHourly = 21
+	WindParameterMeanMaximumHourly = 25
+)
+
+// WindService is a service for the wind queries
+type WindService service
+
+// WindData holds the returned wind data
+// Speeds are given in meters per second and directions in degrees
+type WindData = ObservationData
+
+// WindVector is a wind speed paired with the direction the wind is blowing from
+type WindVector struct {
+	Date      Timestamp
+	Speed     float64 // meters per second
+	Direction float64 // degrees, clockwise from north
+	U         float64 // eastward component in meters per second
+	V         float64 // northward component in meters per second
+}
+
+// NewWindVector creates a wind vector from a speed and a direction
+func NewWindVector(date Timestamp, speed, direction float64) WindVector {
+	rad := direction * math.Pi / 180
+
+	return WindVector{
+		Date:      date,
+		Speed:     speed,
+		Direction: direction,
+		U:         -speed * math.Sin(rad),
+		V:         -speed * math.Cos(rad),
+	}
+}
+
+// CombineWind combines a speed and a direction series from the same station into wind vectors
+// Values without a counterpart in the other series are skipped
+func CombineWind(speed, direction *WindData) ([]WindVector, error) {
+	if speed.Station.Key != direction.Station.Key {
+		return nil, fmt.Errorf("speed from station %s and direction from station %s can not be combined", speed.Station.Key, direction.Station.Key)
+	}
+	switch speed.Parameter.Key {
+	case strconv.Itoa(WindParameterSpeedHourly), strconv.Itoa(WindParameterGustMaximumHourly), strconv.Itoa(WindParameterMeanMaximumHourly):
+	default:
+		return nil, fmt.Errorf("parameter %s is not a wind speed", speed.Parameter.Key)
+	}
+	if direction.Parameter.Key != strconv.Itoa(WindParameterDirectionHourly) {
+		return nil, fmt.Errorf("parameter %s is not a wind direction", direction.Parameter.Key)
+	}
+
+	directions := make(map[Timestamp]float64, len(direction.Value))
+	for _, v := range direction.Value {
+		d := v.Float()
+		if math.IsNaN(d) {
+			continue
+		}
+		directions[v.timestamp()] = d
+	}
+
+	vectors := make([]WindVector, 0, len(speed.Value))
+	for _, v := range speed.Value {
+		d, ok := directions[v.timestamp()]
+		if !ok {
+			continue
+		}
+		s := v.Float()
+		if math.IsNaN(s) {
+			continue
+		}
+		vectors = append(vectors, NewWindVector(v.timestamp(), s, d))
+	}
+
+	return vectors, nil
+}
+
+// GetHourlyDirections retrieves the hourly wind directions from a station
+func (s *WindService) GetHourlyDirections(ctx context.Context, station uint32, period string, opts ...DataOption) (*WindData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), WindParameterDirectionHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyDirections retrieves all stations with hourly wind directions
+func (s *WindService) GetStationsWithHourlyDirections(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), WindParameterDirectionHourly, includeInactive)
+}
+
+// GetHourlySpeeds retrieves the hourly mean wind speeds from a station
+func (s *WindService) GetHourlySpeeds(ctx context.Context, station uint32, period string, opts ...DataOption) (*WindData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), WindParameterSpeedHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlySpeeds retrieves all stations with hourly mean wind speeds
+func (s *WindService) GetStationsWithHourlySpeeds(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), WindParameterSpeedHourly, includeInactive)
+}
+
+// GetHourlyMaximumGusts retrieves the hourly maximum wind gusts from a station
+func (s *WindService) GetHourlyMaximumGusts(ctx context.Context, station uint32, period string, opts ...DataOption) (*WindData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), WindParameterGustMaximumHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyMaximumGusts retrieves all stations with hourly maximum wind gusts
+func (s *WindService) GetStationsWithHourlyMaximumGusts(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), WindParameterGustMaximumHourly, includeInactive)
+}
+
+// GetHourlyMaximumMeanSpeeds retrieves the hourly maximum mean wind speeds from a station
+func (s *WindService) GetHourlyMaximumMeanSpeeds(ctx context.Context, station uint32, period string, opts ...DataOption) (*WindData, *http.Response, error) {
+	return getObservationData(ctx, (*service)(s), WindParameterMeanMaximumHourly, station, period, opts...)
+}
+
+// GetStationsWithHourlyMaximumMeanSpeeds retrieves all stations with hourly maximum mean wind speeds
+func (s *WindService) GetStationsWithHourlyMaximumMeanSpeeds(ctx context.Context, includeInactive bool) (*Parameter, *http.Response, error) {
+	return getParameterData(ctx, (*service)(s), WindParameterMeanMaximumHourly, includeInactive)
+}
+
+// GetHourlyVectors retrieves the hourly mean wind speeds and directions from a station combined as wind vectors
+// The options are applied once to each pair of values, with the worse of their quality codes
+func (s *WindService) GetHourlyVectors(ctx context.Context, station uint32, period string, opts ...DataOption) ([]WindVector, *http.Response, error) {
+	speed, resp, err := s.GetHourlySpeeds(ctx, station, period)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	direction, resp, err := s.GetHourlyDirections(ctx, station, period)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	qualities := make(map[Timestamp]Quality, len(direction.Value))
+	for _, v := range direction.Value {
+		qualities[v.timestamp()] = v.Quality
+	}
+	paired := make([]ObservationValue, 0, len(speed.Value))
+	for _, v := range speed.Value {
+		q, ok := qualities[v.timestamp()]
+		if !ok {
+			continue
+		}
+		v.Quality = worseQuality(v.Quality, q)
+		paired = append(paired, v)
+	}
+	combined := *speed
+	combined.Value = newDataOptions(opts).filter(paired)
+
+	vectors, err := CombineWind(&combined, direction)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return vectors, resp, nil
+}
@@ -0,0 +1,129 @@
+package smhi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCombineWind(t *testing.T) {
+	speed := &WindData{
+		Parameter: ParameterData{Key: "4"},
+		Station:   StationData{Key: "97100"},
+		Value: []ObservationValue{
+			{Date: 1533463200000, Value: "5.0", Quality: "G"},
+			{Date: 1533466800000, Value: "10.0", Quality: "G"},
+			{Date: 1533470400000, Value: "3.0", Quality: "G"},
+		},
+	}
+	direction := &WindData{
+		Parameter: ParameterData{Key: "3"},
+		Station:   StationData{Key: "97100"},
+		Value: []ObservationValue{
+			{Date: 1533463200000, Value: "270", Quality: "G"},
+			{Date: 1533466800000, Value: "180", Quality: "G"},
+		},
+	}
+
+	vectors, err := CombineWind(speed, direction)
+	if err != nil {
+		t.Fatalf("CombineWind returned error: %v", err)
+	}
+
+	want := []WindVector{
+		{Date: 1533463200000, Speed: 5, Direction: 270, U: 5, V: 0},
+		{Date: 1533466800000, Speed: 10, Direction: 180, U: 0, V: 10},
+	}
+	if len(vectors) != len(want) {
+		t.Fatalf("CombineWind returned %d vectors, want %d", len(vectors), len(want))
+	}
+	for i := range want {
+		got := vectors[i]
+		if got.Date != want[i].Date || got.Speed != want[i].Speed || got.Direction != want[i].Direction ||
+			math.Abs(got.U-want[i].U) > 1e-9 || math.Abs(got.V-want[i].V) > 1e-9 {
+			t.Errorf("CombineWind vector %d is %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestCombineWind_differentStations(t *testing.T) {
+	speed := &WindData{Parameter: ParameterData{Key: "4"}, Station: StationData{Key: "97100"}}
+	direction := &WindData{Parameter: ParameterData{Key: "3"}, Station: StationData{Key: "97200"}}
+
+	if _, err := CombineWind(speed, direction); err == nil {
+		t.Errorf("CombineWind expected error for different stations")
+	}
+}
+
+func TestCombineWind_wrongParameters(t *testing.T) {
+	speed := &WindData{Parameter: ParameterData{Key: "4"}, Station: StationData{Key: "97100"}}
+	direction := &WindData{Parameter: ParameterData{Key: "3"}, Station: StationData{Key: "97100"}}
+	temperature := &WindData{Parameter: ParameterData{Key: "1"}, Station: StationData{Key: "97100"}}
+
+	if _, err := CombineWind(direction, speed); err == nil {
+		t.Errorf("CombineWind expected error for swapped parameters")
+	}
+	if _, err := CombineWind(temperature, direction); err == nil {
+		t.Errorf("CombineWind expected error for a speed that is not a wind speed")
+	}
+}
+
+func TestWindService_GetHourlyVectors_returnsOK(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/4/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "4.0", "quality": "G"}],
+		"parameter": {"key": "4"}, "station": {"key": "97100"}}`)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/3/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"value": [{"date": 1533466800000, "value": "90", "quality": "G"}],
+		"parameter": {"key": "3"}, "station": {"key": "97100"}}`)
+	})
+
+	vectors, _, err := client.Wind.GetHourlyVectors(context.Background(), 97100, PeriodLatestHour)
+	if err != nil {
+		t.Fatalf("Wind.GetHourlyVectors returned error: %v", err)
+	}
+	if len(vectors) != 1 {
+		t.Fatalf("Wind.GetHourlyVectors returned %d vectors, want 1", len(vectors))
+	}
+	if got := vectors[0]; math.Abs(got.U+4) > 1e-9 || math.Abs(got.V) > 1e-9 {
+		t.Errorf("Wind.GetHourlyVectors returned %+v, want U -4 and V 0", got)
+	}
+}
+
+func TestWindService_GetHourlyVectors_options(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/version/latest/parameter/4/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"date": 1533463200000, "value": "5.0", "quality": "G"},
+			{"date": 1533466800000, "value": "4.0", "quality": "G"}
+		], "parameter": {"key": "4"}, "station": {"key": "97100"}}`)
+	})
+	mux.HandleFunc("/api/version/latest/parameter/3/station/97100/period/latest-hour/data.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{"date": 1533463200000, "value": "270", "quality": "Y"},
+			{"date": 1533466800000, "value": "90", "quality": "G"}
+		], "parameter": {"key": "3"}, "station": {"key": "97100"}}`)
+	})
+
+	stats := QualityStats{}
+	vectors, _, err := client.Wind.GetHourlyVectors(context.Background(), 97100, PeriodLatestHour, DropSuspect(), WithQualityStats(stats))
+	if err != nil {
+		t.Fatalf("Wind.GetHourlyVectors returned error: %v", err)
+	}
+	if len(vectors) != 1 || vectors[0].Date != 1533466800000 {
+		t.Errorf("Wind.GetHourlyVectors returned %+v, want the vector at 1533466800000", vectors)
+	}
+	if want := (QualityStats{QualityControlled: 1, QualitySuspect: 1}); !reflect.DeepEqual(stats, want) {
+		t.Errorf("Wind.GetHourlyVectors counted %v, want %v", stats, want)
+	}
+}